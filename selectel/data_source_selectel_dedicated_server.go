@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
 	"log"
+	"time"
 )
 
 // Data Source: Location
@@ -30,30 +31,37 @@ func dataSourceDedicatedServerLocationV1Read(ctx context.Context, d *schema.Reso
 		return diag.FromErr(fmt.Errorf("error reading locations: %w", err))
 	}
 
-	var targetLocation *ddaas.Location
+	filterSet := d.Get("filter").(*schema.Set)
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Поиск по UUID или имени
+	var candidates []ddaas.Location
 	if uuid, ok := d.GetOk("uuid"); ok {
 		for _, location := range locations {
 			if location.UUID == uuid.(string) {
-				targetLocation = &location
-				break
+				candidates = append(candidates, location)
 			}
 		}
 	} else if name, ok := d.GetOk("name"); ok {
 		for _, location := range locations {
 			if location.Name == name.(string) {
-				targetLocation = &location
-				break
+				candidates = append(candidates, location)
 			}
 		}
+	} else if filterSet.Len() > 0 {
+		candidates = locations
 	} else {
-		return diag.Errorf("either 'uuid' or 'name' must be specified")
+		return diag.Errorf("one of 'uuid', 'name' or 'filter' must be specified")
 	}
 
-	if targetLocation == nil {
-		return diag.Errorf("location not found")
+	candidates = filterDedicatedServerLocations(candidates, filter)
+	if err := checkDedicatedServerCatalogMatches("location", len(candidates), filterSet.Len() > 0); err != nil {
+		return diag.FromErr(err)
 	}
+	targetLocation := candidates[0]
 
 	d.SetId(targetLocation.UUID)
 	d.Set("uuid", targetLocation.UUID)
@@ -87,30 +95,37 @@ func dataSourceDedicatedServerConfigurationV1Read(ctx context.Context, d *schema
 		return diag.FromErr(fmt.Errorf("error reading configurations: %w", err))
 	}
 
-	var targetConfiguration *ddaas.Configuration
+	filterSet := d.Get("filter").(*schema.Set)
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Поиск по UUID или имени
+	var candidates []ddaas.Configuration
 	if uuid, ok := d.GetOk("uuid"); ok {
 		for _, config := range configurations {
 			if config.UUID == uuid.(string) {
-				targetConfiguration = &config
-				break
+				candidates = append(candidates, config)
 			}
 		}
 	} else if name, ok := d.GetOk("name"); ok {
 		for _, config := range configurations {
 			if config.Name == name.(string) {
-				targetConfiguration = &config
-				break
+				candidates = append(candidates, config)
 			}
 		}
+	} else if filterSet.Len() > 0 {
+		candidates = configurations
 	} else {
-		return diag.Errorf("either 'uuid' or 'name' must be specified")
+		return diag.Errorf("one of 'uuid', 'name' or 'filter' must be specified")
 	}
 
-	if targetConfiguration == nil {
-		return diag.Errorf("configuration not found")
+	candidates = filterDedicatedServerConfigurations(candidates, filter)
+	if err := checkDedicatedServerCatalogMatches("configuration", len(candidates), filterSet.Len() > 0); err != nil {
+		return diag.FromErr(err)
 	}
+	targetConfiguration := candidates[0]
 
 	d.SetId(targetConfiguration.UUID)
 	d.Set("uuid", targetConfiguration.UUID)
@@ -146,30 +161,37 @@ func dataSourceDedicatedServerTariffV1Read(ctx context.Context, d *schema.Resour
 		return diag.FromErr(fmt.Errorf("error reading tariffs: %w", err))
 	}
 
-	var targetTariff *ddaas.Tariff
+	filterSet := d.Get("filter").(*schema.Set)
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Поиск по UUID или имени
+	var candidates []ddaas.Tariff
 	if uuid, ok := d.GetOk("uuid"); ok {
 		for _, tariff := range tariffs {
 			if tariff.UUID == uuid.(string) {
-				targetTariff = &tariff
-				break
+				candidates = append(candidates, tariff)
 			}
 		}
 	} else if name, ok := d.GetOk("name"); ok {
 		for _, tariff := range tariffs {
 			if tariff.Name == name.(string) {
-				targetTariff = &tariff
-				break
+				candidates = append(candidates, tariff)
 			}
 		}
+	} else if filterSet.Len() > 0 {
+		candidates = tariffs
 	} else {
-		return diag.Errorf("either 'uuid' or 'name' must be specified")
+		return diag.Errorf("one of 'uuid', 'name' or 'filter' must be specified")
 	}
 
-	if targetTariff == nil {
-		return diag.Errorf("tariff not found")
+	candidates = filterDedicatedServerTariffs(candidates, filter)
+	if err := checkDedicatedServerCatalogMatches("tariff", len(candidates), filterSet.Len() > 0); err != nil {
+		return diag.FromErr(err)
 	}
+	targetTariff := candidates[0]
 
 	d.SetId(targetTariff.UUID)
 	d.Set("uuid", targetTariff.UUID)
@@ -205,38 +227,50 @@ func dataSourceDedicatedServerOSImageV1Read(ctx context.Context, d *schema.Resou
 		return diag.FromErr(fmt.Errorf("error reading OS images: %w", err))
 	}
 
-	var targetImage *ddaas.OSImage
+	filterSet := d.Get("filter").(*schema.Set)
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Поиск по UUID, имени или семейству
+	var candidates []ddaas.OSImage
 	if uuid, ok := d.GetOk("uuid"); ok {
 		for _, image := range images {
 			if image.UUID == uuid.(string) {
-				targetImage = &image
-				break
+				candidates = append(candidates, image)
 			}
 		}
 	} else if name, ok := d.GetOk("name"); ok {
 		for _, image := range images {
 			if image.Name == name.(string) {
-				targetImage = &image
-				break
+				candidates = append(candidates, image)
 			}
 		}
 	} else if family, ok := d.GetOk("family"); ok {
-		// Найти первый образ указанного семейства
+		// Найти образы указанного семейства
 		for _, image := range images {
 			if image.Family == family.(string) {
-				targetImage = &image
-				break
+				candidates = append(candidates, image)
 			}
 		}
+	} else if filterSet.Len() > 0 {
+		candidates = images
 	} else {
-		return diag.Errorf("one of 'uuid', 'name', or 'family' must be specified")
+		return diag.Errorf("one of 'uuid', 'name', 'family' or 'filter' must be specified")
 	}
 
-	if targetImage == nil {
-		return diag.Errorf("OS image not found")
+	candidates = filterDedicatedServerOSImages(candidates, filter)
+
+	// При most_recent выбирается самый новый из подходящих образов
+	mostRecent := d.Get("most_recent").(bool)
+	if mostRecent {
+		sortDedicatedServerOSImages(candidates, d.Get("sort_by").(string))
+	}
+	if err := checkDedicatedServerCatalogMatches("OS image", len(candidates), filterSet.Len() > 0 && !mostRecent); err != nil {
+		return diag.FromErr(err)
 	}
+	targetImage := candidates[0]
 
 	d.SetId(targetImage.UUID)
 	d.Set("uuid", targetImage.UUID)
@@ -271,38 +305,44 @@ func dataSourceDedicatedServerNetworkV1Read(ctx context.Context, d *schema.Resou
 		return diag.FromErr(fmt.Errorf("error reading networks: %w", err))
 	}
 
-	var targetNetwork *ddaas.Network
+	filterSet := d.Get("filter").(*schema.Set)
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Поиск по UUID, имени или типу
+	var candidates []ddaas.Network
 	if uuid, ok := d.GetOk("uuid"); ok {
 		for _, network := range networks {
 			if network.UUID == uuid.(string) {
-				targetNetwork = &network
-				break
+				candidates = append(candidates, network)
 			}
 		}
 	} else if name, ok := d.GetOk("name"); ok {
 		for _, network := range networks {
 			if network.Name == name.(string) {
-				targetNetwork = &network
-				break
+				candidates = append(candidates, network)
 			}
 		}
 	} else if networkType, ok := d.GetOk("type"); ok {
-		// Найти первую сеть указанного типа
+		// Найти сети указанного типа
 		for _, network := range networks {
 			if network.Type == networkType.(string) {
-				targetNetwork = &network
-				break
+				candidates = append(candidates, network)
 			}
 		}
+	} else if filterSet.Len() > 0 {
+		candidates = networks
 	} else {
-		return diag.Errorf("one of 'uuid', 'name', or 'type' must be specified")
+		return diag.Errorf("one of 'uuid', 'name', 'type' or 'filter' must be specified")
 	}
 
-	if targetNetwork == nil {
-		return diag.Errorf("network not found")
+	candidates = filterDedicatedServerNetworks(candidates, filter)
+	if err := checkDedicatedServerCatalogMatches("network", len(candidates), filterSet.Len() > 0); err != nil {
+		return diag.FromErr(err)
 	}
+	targetNetwork := candidates[0]
 
 	d.SetId(targetNetwork.UUID)
 	d.Set("uuid", targetNetwork.UUID)
@@ -318,11 +358,21 @@ func dataSourceDedicatedServerLocationsV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerLocationsV1Read,
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region of the dedicated server API endpoint",
+			},
 			"locations": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: dataSourceDedicatedServerLocationV1Schema(),
+					Schema: dedicatedServerV1CatalogItemSchema(dataSourceDedicatedServerLocationV1Schema()),
 				},
 			},
 		},
@@ -364,6 +414,16 @@ func dataSourceDedicatedServerConfigurationsV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerConfigurationsV1Read,
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region of the dedicated server API endpoint",
+			},
 			"location_uuid": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -373,7 +433,7 @@ func dataSourceDedicatedServerConfigurationsV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: dataSourceDedicatedServerConfigurationV1Schema(),
+					Schema: dedicatedServerV1CatalogItemSchema(dataSourceDedicatedServerConfigurationV1Schema()),
 				},
 			},
 		},
@@ -418,6 +478,16 @@ func dataSourceDedicatedServerTariffsV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerTariffsV1Read,
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region of the dedicated server API endpoint",
+			},
 			"configuration_uuid": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -427,7 +497,7 @@ func dataSourceDedicatedServerTariffsV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: dataSourceDedicatedServerTariffV1Schema(),
+					Schema: dedicatedServerV1CatalogItemSchema(dataSourceDedicatedServerTariffV1Schema()),
 				},
 			},
 		},
@@ -470,6 +540,16 @@ func dataSourceDedicatedServerOSImagesV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerOSImagesV1Read,
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region of the dedicated server API endpoint",
+			},
 			"location_uuid": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -489,7 +569,7 @@ func dataSourceDedicatedServerOSImagesV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: dataSourceDedicatedServerOSImageV1Schema(),
+					Schema: dedicatedServerV1CatalogItemSchema(dataSourceDedicatedServerOSImageV1Schema()),
 				},
 			},
 		},
@@ -548,6 +628,16 @@ func dataSourceDedicatedServerNetworksV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerNetworksV1Read,
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region of the dedicated server API endpoint",
+			},
 			"location_uuid": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -562,7 +652,7 @@ func dataSourceDedicatedServerNetworksV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: dataSourceDedicatedServerNetworkV1Schema(),
+					Schema: dedicatedServerV1CatalogItemSchema(dataSourceDedicatedServerNetworkV1Schema()),
 				},
 			},
 		},
@@ -612,3 +702,541 @@ func dataSourceDedicatedServerNetworksV1Read(ctx context.Context, d *schema.Reso
 
 	return nil
 }
+
+// Data Source: Server
+func dataSourceDedicatedServerV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerV1Read,
+		Schema:      dataSourceDedicatedServerV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("[DEBUG] Reading dedicated server")
+
+	var targetServer *ddaas.DedicatedServer
+
+	// Поиск по UUID или имени
+	if uuid, ok := d.GetOk("uuid"); ok {
+		server, err := client.DedicatedServer(ctx, uuid.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading dedicated server %s: %w", uuid, err))
+		}
+		if projectID := d.Get("project_id").(string); server.ProjectID != projectID {
+			return diag.Errorf("dedicated server %s doesn't belong to project %s", uuid, projectID)
+		}
+		targetServer = &server
+	} else if name, ok := d.GetOk("name"); ok {
+		servers, err := client.DedicatedServers(ctx, &ddaas.DedicatedServerQueryParams{
+			ProjectID: d.Get("project_id").(string),
+			Name:      name.(string),
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading dedicated servers: %w", err))
+		}
+
+		// API может искать по подстроке, поэтому сравниваем имя целиком
+		for _, server := range servers {
+			if server.Name != name.(string) {
+				continue
+			}
+			if targetServer != nil {
+				return diag.Errorf("found multiple dedicated servers with name '%s', use 'uuid' instead", name)
+			}
+			targetServer = &server
+		}
+	} else {
+		return diag.Errorf("either 'uuid' or 'name' must be specified")
+	}
+
+	if targetServer == nil {
+		return diag.Errorf("dedicated server not found")
+	}
+
+	configurations, tariffs, err := getDedicatedServerV1Catalog(ctx, client, []ddaas.DedicatedServer{*targetServer})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Адреса привязанных к серверу подсетей, как и в ресурсе
+	subnets, err := client.IPSubnets(ctx, &ddaas.IPSubnetQueryParams{
+		ServerUUID: targetServer.UUID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading IP subnets of dedicated server %s: %w", targetServer.UUID, err))
+	}
+
+	d.SetId(targetServer.UUID)
+	for key, value := range flattenDedicatedServerV1(*targetServer, subnets, configurations, tariffs) {
+		if err := d.Set(key, value); err != nil {
+			log.Print(errSettingComplexAttr(key, err))
+		}
+	}
+
+	return nil
+}
+
+// Data Source: Multiple Servers
+func dataSourceDedicatedServersV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServersV1Read,
+		Schema:      dataSourceDedicatedServersV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServersV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("[DEBUG] Reading all dedicated servers")
+
+	projectID := d.Get("project_id").(string)
+	locationUUID := d.Get("location_uuid").(string)
+
+	servers, err := client.DedicatedServers(ctx, &ddaas.DedicatedServerQueryParams{
+		ProjectID: projectID,
+		Status:    ddaas.Status(d.Get("status").(string)),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading dedicated servers: %w", err))
+	}
+
+	// Фильтрация по локации если указано
+	filtered := make([]ddaas.DedicatedServer, 0, len(servers))
+	for _, server := range servers {
+		if locationUUID == "" || server.LocationUUID == locationUUID {
+			filtered = append(filtered, server)
+		}
+	}
+
+	configurations, tariffs, err := getDedicatedServerV1Catalog(ctx, client, filtered)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Адреса привязанных к серверам подсетей
+	subnets, err := client.IPSubnets(ctx, &ddaas.IPSubnetQueryParams{
+		LocationUUID: locationUUID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading IP subnets: %w", err))
+	}
+	subnetsByServer := make(map[string][]ddaas.IPSubnet)
+	for _, subnet := range subnets {
+		if subnet.ServerUUID != "" {
+			subnetsByServer[subnet.ServerUUID] = append(subnetsByServer[subnet.ServerUUID], subnet)
+		}
+	}
+
+	serversList := make([]map[string]interface{}, 0, len(filtered))
+	for _, server := range filtered {
+		serversList = append(serversList, flattenDedicatedServerV1(server, subnetsByServer[server.UUID], configurations, tariffs))
+	}
+
+	d.SetId(fmt.Sprintf("servers/%s", projectID))
+	if err := d.Set("servers", serversList); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting servers: %w", err))
+	}
+
+	return nil
+}
+
+// Data Source: Availability
+func dataSourceDedicatedServerAvailabilityV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerAvailabilityV1Read,
+		Schema:      dataSourceDedicatedServerAvailabilityV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerAvailabilityV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("[DEBUG] Reading dedicated server availability")
+
+	locationUUID := d.Get("location_uuid").(string)
+	configUUID := d.Get("configuration_uuid").(string)
+
+	availability, err := client.Availability(ctx, &ddaas.AvailabilityQueryParams{
+		LocationUUID:      locationUUID,
+		ConfigurationUUID: configUUID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading availability: %w", err))
+	}
+
+	availabilityList := make([]map[string]interface{}, len(availability))
+	for i, item := range availability {
+		availabilityList[i] = map[string]interface{}{
+			"configuration_uuid": item.ConfigurationUUID,
+			"location_uuid":      item.LocationUUID,
+			"available":          item.Available,
+		}
+	}
+
+	d.SetId(fmt.Sprintf("availability/%s/%s", locationUUID, configUUID))
+	d.Set("availability", availabilityList)
+
+	return nil
+}
+
+// Data Source: Price Estimate
+func dataSourceDedicatedServerPriceEstimateV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerPriceEstimateV1Read,
+		Schema:      dataSourceDedicatedServerPriceEstimateV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerPriceEstimateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("[DEBUG] Reading dedicated server price estimate")
+
+	configUUID := d.Get("configuration_uuid").(string)
+	tariffs, err := client.Tariffs(ctx, configUUID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading tariffs: %w", err))
+	}
+
+	// Если тариф не указан, используем самый дешевый, иначе самый дешевый
+	// ищется среди тарифов в валюте указанного
+	var targetTariff, cheapest ddaas.Tariff
+	if tariffUUID := d.Get("tariff_uuid").(string); tariffUUID != "" {
+		found := false
+		for _, tariff := range tariffs {
+			if tariff.UUID == tariffUUID {
+				targetTariff, found = tariff, true
+				break
+			}
+		}
+		if !found {
+			return diag.Errorf("tariff %s not available for configuration %s", tariffUUID, configUUID)
+		}
+
+		cheapest, err = cheapestTariff(tariffs, targetTariff.Currency)
+		if err != nil {
+			return diag.Errorf("can't find the cheapest tariff of configuration %s: %s", configUUID, err)
+		}
+	} else {
+		cheapest, err = cheapestTariff(tariffs, "")
+		if err != nil {
+			return diag.Errorf("can't find the cheapest tariff of configuration %s: %s, set tariff_uuid to choose one", configUUID, err)
+		}
+		targetTariff = cheapest
+	}
+
+	publicIPs := d.Get("additional_public_ips").(int)
+	privateNetwork := d.Get("private_network").(bool)
+
+	var options []ddaas.PriceOption
+	if publicIPs > 0 || privateNetwork {
+		options, err = client.PriceOptions(ctx, d.Get("location_uuid").(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading option prices: %w", err))
+		}
+	}
+
+	estimate, err := estimateDedicatedServerPrice(targetTariff, options, publicIPs, privateNetwork, d.Get("server_count").(int))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error estimating price: %w", err))
+	}
+
+	tariffsList := make([]map[string]interface{}, 0, len(tariffs))
+	for _, tariff := range tariffs {
+		monthlyPrice, err := tariff.MonthlyPrice()
+		if err != nil {
+			continue
+		}
+		tariffsList = append(tariffsList, map[string]interface{}{
+			"uuid":          tariff.UUID,
+			"name":          tariff.Name,
+			"period":        tariff.Period,
+			"price":         tariff.Price,
+			"monthly_price": roundPrice(monthlyPrice),
+			"currency":      tariff.Currency,
+			"cheapest":      tariff.UUID == cheapest.UUID,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("price_estimate/%s/%s", configUUID, targetTariff.UUID))
+	d.Set("tariff_uuid", targetTariff.UUID)
+	d.Set("currency", estimate.Currency)
+	d.Set("period", targetTariff.Period)
+	d.Set("monthly_total", estimate.MonthlyTotal)
+	d.Set("period_total", estimate.PeriodTotal)
+	d.Set("cheapest_tariff_uuid", cheapest.UUID)
+	d.Set("tariffs", tariffsList)
+
+	return nil
+}
+
+// Data Source: Console
+func dataSourceDedicatedServerConsoleV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerConsoleV1Read,
+		Schema:      dataSourceDedicatedServerConsoleV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerConsoleV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverUUID := d.Get("server_uuid").(string)
+	consoleType := ddaas.ConsoleType(d.Get("type").(string))
+
+	log.Printf("[DEBUG] Opening %s console for dedicated server %s", consoleType, serverUUID)
+
+	// Каждое чтение открывает новую сессию, так как доступ ограничен по времени.
+	// Data source читается при каждом plan, это побочный эффект, описанный в документации
+	console, err := client.CreateDedicatedServerConsole(ctx, serverUUID, ddaas.ConsoleCreateOpts{
+		Type: consoleType,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error opening console for dedicated server %s: %w", serverUUID, err))
+	}
+
+	d.SetId(fmt.Sprintf("console/%s/%s", serverUUID, consoleType))
+	d.Set("url", console.URL)
+	d.Set("host", console.Host)
+	d.Set("username", console.Username)
+	d.Set("password", console.Password)
+	if !console.ExpiresAt.IsZero() {
+		d.Set("expires_at", console.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// Data Source: Hardware
+func dataSourceDedicatedServerHardwareV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerHardwareV1Read,
+		Schema:      dataSourceDedicatedServerHardwareV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerHardwareV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("[DEBUG] Reading dedicated server hardware")
+
+	var (
+		id       string
+		hardware ddaas.Hardware
+	)
+
+	configUUID := d.Get("configuration_uuid").(string)
+	if serverUUID := d.Get("server_uuid").(string); serverUUID != "" {
+		// Для сервера читаются фактически установленные компоненты и их состояние
+		server, err := client.DedicatedServer(ctx, serverUUID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading dedicated server %s: %w", serverUUID, err))
+		}
+
+		serverHardware, err := client.DedicatedServerHardware(ctx, serverUUID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading hardware of dedicated server %s: %w", serverUUID, err))
+		}
+
+		id = fmt.Sprintf("hardware/server/%s", serverUUID)
+		configUUID = server.ConfigurationUUID
+		hardware = serverHardware.Hardware()
+		d.Set("status", string(server.Status))
+	} else {
+		// Для конфигурации без заказанного сервера разбирается ее описание в каталоге
+		configuration, err := client.Configuration(ctx, configUUID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading configuration %s: %w", configUUID, err))
+		}
+
+		hardware, err = configuration.Hardware()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error parsing hardware of configuration %s: %w", configUUID, err))
+		}
+
+		id = fmt.Sprintf("hardware/configuration/%s", configUUID)
+	}
+
+	disksList := make([]map[string]interface{}, len(hardware.Disks))
+	for i, disk := range hardware.Disks {
+		disksList[i] = map[string]interface{}{
+			"type":          disk.Type,
+			"size_gb":       disk.SizeGB,
+			"model":         disk.Model,
+			"serial_number": disk.SerialNumber,
+			"health":        disk.Health,
+		}
+	}
+
+	d.SetId(id)
+	d.Set("configuration_uuid", configUUID)
+	d.Set("cpu_model", hardware.CPU.Model)
+	d.Set("cpu_sockets", hardware.CPU.Sockets)
+	d.Set("cpu_cores", hardware.CPU.Cores)
+	d.Set("cpu_frequency_ghz", hardware.CPU.FrequencyGHz)
+	d.Set("ram_gb", hardware.RAMGB)
+	d.Set("disk_count", len(hardware.Disks))
+	d.Set("disks", disksList)
+	d.Set("health", hardware.Health)
+
+	return nil
+}
+
+// Data Source: Traffic
+func dataSourceDedicatedServerTrafficV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerTrafficV1Read,
+		Schema:      dataSourceDedicatedServerTrafficV1Schema(),
+	}
+}
+
+func dataSourceDedicatedServerTrafficV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverUUID := d.Get("server_uuid").(string)
+	from, to, err := dedicatedServerTrafficPeriod(d.Get("from").(string), d.Get("to").(string), time.Now().UTC())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Reading traffic of dedicated server %s from %s to %s", serverUUID, from, to)
+
+	traffic, err := client.DedicatedServerTraffic(ctx, serverUUID, &ddaas.TrafficQueryParams{
+		From: from.Format(time.RFC3339),
+		To:   to.Format(time.RFC3339),
+		IP:   d.Get("ip").(string),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading traffic of dedicated server %s: %w", serverUUID, err))
+	}
+
+	ipsList := make([]map[string]interface{}, len(traffic.IPs))
+	for i, ip := range traffic.IPs {
+		ipsList[i] = map[string]interface{}{
+			"ip":                 ip.IP,
+			"inbound_bytes":      int(ip.InboundBytes),
+			"outbound_bytes":     int(ip.OutboundBytes),
+			"inbound_95th_mbps":  ip.Inbound95thMbps,
+			"outbound_95th_mbps": ip.Outbound95thMbps,
+		}
+	}
+
+	// Конец периода по умолчанию меняется при каждом чтении, поэтому
+	// в ID попадает только указанный в конфигурации
+	id := fmt.Sprintf("traffic/%s/%s", serverUUID, from.Format(time.RFC3339))
+	if d.Get("to").(string) != "" {
+		id = fmt.Sprintf("%s/%s", id, to.Format(time.RFC3339))
+	}
+
+	d.SetId(id)
+	d.Set("from", from.Format(time.RFC3339))
+	d.Set("to", to.Format(time.RFC3339))
+	d.Set("inbound_bytes", int(traffic.Total.InboundBytes))
+	d.Set("outbound_bytes", int(traffic.Total.OutboundBytes))
+	d.Set("inbound_95th_mbps", traffic.Total.Inbound95thMbps)
+	d.Set("outbound_95th_mbps", traffic.Total.Outbound95thMbps)
+	if err := d.Set("ip_addresses", ipsList); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ip_addresses: %w", err))
+	}
+
+	return nil
+}
+
+// dedicatedServerTrafficPeriod возвращает период статистики трафика. По умолчанию
+// используется текущий расчетный месяц: с начала месяца до текущего момента.
+func dedicatedServerTrafficPeriod(fromValue, toValue string, now time.Time) (time.Time, time.Time, error) {
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+
+	if fromValue != "" {
+		parsed, err := time.Parse(time.RFC3339, fromValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+		}
+		from = parsed
+	}
+	if toValue != "" {
+		parsed, err := time.Parse(time.RFC3339, toValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+		}
+		to = parsed
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	return from, to, nil
+}
+
+// getDedicatedServerV1Catalog возвращает конфигурации и тарифы серверов,
+// проиндексированные по UUID. Запрашиваются только тарифы конфигураций серверов,
+// а обход конфигураций прекращается, когда найдены все нужные.
+func getDedicatedServerV1Catalog(
+	ctx context.Context, client *ddaas.API, servers []ddaas.DedicatedServer,
+) (map[string]ddaas.Configuration, map[string]ddaas.Tariff, error) {
+	configurationUUIDs := make(map[string]struct{})
+	tariffUUIDs := make(map[string]struct{})
+	for _, server := range servers {
+		if server.ConfigurationUUID != "" {
+			configurationUUIDs[server.ConfigurationUUID] = struct{}{}
+		}
+		if server.TariffUUID != "" {
+			tariffUUIDs[server.TariffUUID] = struct{}{}
+		}
+	}
+
+	configurationsByUUID := make(map[string]ddaas.Configuration, len(configurationUUIDs))
+	if len(configurationUUIDs) > 0 {
+		for config, err := range client.ConfigurationsIter(ctx, "") {
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading configurations: %w", err)
+			}
+			if _, ok := configurationUUIDs[config.UUID]; !ok {
+				continue
+			}
+			configurationsByUUID[config.UUID] = config
+			if len(configurationsByUUID) == len(configurationUUIDs) {
+				break
+			}
+		}
+	}
+
+	tariffsByUUID := make(map[string]ddaas.Tariff, len(tariffUUIDs))
+	for configUUID := range configurationUUIDs {
+		for tariff, err := range client.TariffsIter(ctx, configUUID) {
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading tariffs of configuration %s: %w", configUUID, err)
+			}
+			if _, ok := tariffUUIDs[tariff.UUID]; ok {
+				tariffsByUUID[tariff.UUID] = tariff
+			}
+		}
+	}
+
+	return configurationsByUUID, tariffsByUUID, nil
+}
@@ -0,0 +1,201 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func dataSourceMKSClusterV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMKSClusterV1Read,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"cluster_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"cluster_id", "name"},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kube_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kube_api_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"maintenance_window_start": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"maintenance_window_end": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enable_autorepair": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_patch_version_auto_upgrade": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_pod_security_policy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_audit_logs": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"zonal": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"private_kube_api": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			featureGatesKey: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			admissionControllersKey: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"oidc": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"provider_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username_claim": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"groups_claim": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ca_certs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMKSClusterV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	mksClient, diagErr := getMKSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	var mksCluster *cluster.View
+	if clusterID := d.Get("cluster_id").(string); clusterID != "" {
+		log.Print(msgGet(objectCluster, clusterID))
+		view, _, err := cluster.Get(ctx, mksClient, clusterID)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectCluster, clusterID, err))
+		}
+		mksCluster = view
+	} else {
+		name := d.Get("name").(string)
+		clusters, _, err := cluster.List(ctx, mksClient)
+		if err != nil {
+			return diag.FromErr(errGettingObjects(objectClusters, err))
+		}
+		mksCluster, err = findMKSClusterV1ByName(clusters, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(mksCluster.ID)
+	d.Set("cluster_id", mksCluster.ID)
+	d.Set("name", mksCluster.Name)
+	d.Set("status", mksCluster.Status)
+	d.Set("kube_version", mksCluster.KubeVersion)
+	d.Set("network_id", mksCluster.NetworkID)
+	d.Set("subnet_id", mksCluster.SubnetID)
+	d.Set("kube_api_ip", mksCluster.KubeAPIIP)
+	d.Set("maintenance_window_start", mksCluster.MaintenanceWindowStart)
+	d.Set("maintenance_window_end", mksCluster.MaintenanceWindowEnd)
+	d.Set("enable_autorepair", mksCluster.EnableAutorepair)
+	d.Set("enable_patch_version_auto_upgrade", mksCluster.EnablePatchVersionAutoUpgrade)
+	d.Set("zonal", mksCluster.Zonal)
+	d.Set("private_kube_api", mksCluster.PrivateKubeAPI)
+
+	if mksCluster.KubernetesOptions != nil {
+		d.Set("enable_pod_security_policy", mksCluster.KubernetesOptions.EnablePodSecurityPolicy)
+		d.Set("enable_audit_logs", mksCluster.KubernetesOptions.AuditLogs.Enabled)
+
+		if err := d.Set(featureGatesKey, mksCluster.KubernetesOptions.FeatureGates); err != nil {
+			log.Print(errSettingComplexAttr(featureGatesKey, err))
+		}
+		if err := d.Set(admissionControllersKey, mksCluster.KubernetesOptions.AdmissionControllers); err != nil {
+			log.Print(errSettingComplexAttr(admissionControllersKey, err))
+		}
+		if err := d.Set("oidc", flattenMKSClusterV1OIDC(mksCluster)); err != nil {
+			log.Print(errSettingComplexAttr("oidc", err))
+		}
+	}
+
+	return nil
+}
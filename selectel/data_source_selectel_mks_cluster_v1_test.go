@@ -0,0 +1,56 @@
+package selectel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMKSClusterV1DataSourceBasic(t *testing.T) {
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMKSClusterV1DataSourceBasic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_id", "name", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "name"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_id", "kube_version", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "kube_version"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_id", "network_id", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "network_id"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_id", "subnet_id", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "subnet_id"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_id", "kube_api_ip", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "kube_api_ip"),
+					resource.TestCheckResourceAttr("data.selectel_mks_cluster_v1.cluster_by_id", "oidc.#", "1"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_name", "cluster_id", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_cluster_v1.cluster_by_name", "kube_api_ip", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "kube_api_ip"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMKSClusterV1DataSourceBasic(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
+	return fmt.Sprintf(`
+%s
+
+data "selectel_mks_cluster_v1" "cluster_by_id" {
+  cluster_id = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+}
+
+data "selectel_mks_cluster_v1" "cluster_by_name" {
+  name       = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.name}"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+}
+`, testAccMKSClusterV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart))
+}
@@ -0,0 +1,164 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func dataSourceMKSNodegroupsV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMKSNodegroupsV1Read,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"nodegroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_gb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"volume_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_volume": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"nodes_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"enable_autoscale": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"autoscale_min_nodes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autoscale_max_nodes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nodegroup_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"install_nvidia_device_plugin": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"preemptible": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"taints": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"effect": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"nodes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"hostname": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMKSNodegroupsV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	mksClient, diagErr := getMKSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+
+	log.Print(msgGet(objectNodegroups, clusterID))
+	nodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectNodegroups, clusterID, err))
+	}
+
+	if err := d.Set("nodegroups", flattenMKSNodegroupsV1(nodegroups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clusterID)
+
+	return nil
+}
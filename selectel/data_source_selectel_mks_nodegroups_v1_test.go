@@ -0,0 +1,49 @@
+package selectel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMKSNodegroupsV1DataSourceBasic(t *testing.T) {
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMKSNodegroupsV1DataSourceBasic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.#", "1"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.flavor_id", "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "flavor_id"),
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.availability_zone", "ru-9a"),
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.nodes_count", "2"),
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.nodes.#", "2"),
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.labels.label-key0", "label-value0"),
+					resource.TestCheckResourceAttr("data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1", "nodegroups.0.taints.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMKSNodegroupsV1DataSourceBasic(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
+	return fmt.Sprintf(`
+%s
+
+data "selectel_mks_nodegroups_v1" "nodegroups_tf_acc_test_1" {
+  cluster_id = "${selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1.cluster_id}"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+}
+`, testAccMKSNodegroupV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart))
+}
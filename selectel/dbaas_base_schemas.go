@@ -8,6 +8,7 @@ import (
 
 func resourceDBaaSDatastoreV1BaseSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"deletion_protection": deletionProtectionSchema(),
 		"name": {
 			Type:     schema.TypeString,
 			Required: true,
@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
+	"net"
 	"net/http"
-	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -105,6 +108,51 @@ type OSImage struct {
 	Parameters   map[string]string `json:"parameters,omitempty"`
 }
 
+// Типы значений параметров образа ОС
+const (
+	OSParameterTypeString = "string"
+	OSParameterTypeBool   = "bool"
+	OSParameterTypeInt    = "int"
+)
+
+// OSParameter описание параметра, который принимает образ ОС
+type OSParameter struct {
+	Name     string
+	Type     string
+	Required bool
+}
+
+// osParameterSpecs описания параметров образа ОС, которые понимает провайдер.
+var osParameterSpecs = map[string]OSParameter{
+	"string":          {Type: OSParameterTypeString},
+	"string,required": {Type: OSParameterTypeString, Required: true},
+	"bool":            {Type: OSParameterTypeBool},
+	"bool,required":   {Type: OSParameterTypeBool, Required: true},
+	"int":             {Type: OSParameterTypeInt},
+	"int,required":    {Type: OSParameterTypeInt, Required: true},
+}
+
+// ParameterSchema разбирает Parameters образа в типизированное описание.
+// Возвращаются только параметры с описанием из osParameterSpecs, например
+// "string,required"; параметры с другими описаниями попадают в unrecognized
+// и не проверяются.
+func (i OSImage) ParameterSchema() (parameters map[string]OSParameter, unrecognized []string) {
+	parameters = make(map[string]OSParameter, len(i.Parameters))
+	for name, spec := range i.Parameters {
+		parameter, ok := osParameterSpecs[strings.ToLower(strings.ReplaceAll(spec, " ", ""))]
+		if !ok {
+			unrecognized = append(unrecognized, name)
+			continue
+		}
+
+		parameter.Name = name
+		parameters[name] = parameter
+	}
+	sort.Strings(unrecognized)
+
+	return parameters, unrecognized
+}
+
 // Network представляет сеть
 type Network struct {
 	UUID         string `json:"uuid"`
@@ -114,6 +162,107 @@ type Network struct {
 	VLAN         int    `json:"vlan,omitempty"`
 }
 
+// IPSubnet представляет дополнительную подсеть публичных IP адресов
+type IPSubnet struct {
+	UUID         string   `json:"uuid"`
+	LocationUUID string   `json:"location_uuid"`
+	ServerUUID   string   `json:"server_uuid,omitempty"`
+	IPVersion    int      `json:"ip_version"`
+	PrefixLength int      `json:"prefix_length"`
+	CIDR         string   `json:"cidr"`
+	Gateway      string   `json:"gateway"`
+	Addresses    []string `json:"addresses,omitempty"`
+	Status       string   `json:"status"`
+}
+
+// IPAddresses возвращает адреса подсети в том же виде, что и адреса сервера
+func (s IPSubnet) IPAddresses() []IPAddress {
+	netmask := ""
+	if s.IPVersion != IPVersion6 {
+		netmask = net.IP(net.CIDRMask(s.PrefixLength, 32)).String()
+	}
+
+	addresses := make([]IPAddress, len(s.Addresses))
+	for i, address := range s.Addresses {
+		addresses[i] = IPAddress{
+			Type:         "public",
+			IP:           address,
+			IPVersion:    s.IPVersion,
+			PrefixLength: s.PrefixLength,
+			Netmask:      netmask,
+			Gateway:      s.Gateway,
+		}
+	}
+
+	return addresses
+}
+
+// IPSubnetCreateOpts параметры заказа подсети
+type IPSubnetCreateOpts struct {
+	LocationUUID string `json:"location_uuid"`
+	IPVersion    int    `json:"ip_version"`
+	PrefixLength int    `json:"prefix_length"`
+	ServerUUID   string `json:"server_uuid,omitempty"`
+}
+
+// IPSubnetUpdateOpts параметры привязки подсети к серверу,
+// пустой ServerUUID отвязывает подсеть
+type IPSubnetUpdateOpts struct {
+	ServerUUID string `json:"server_uuid"`
+}
+
+// IPSubnetQueryParams параметры поиска подсетей
+type IPSubnetQueryParams struct {
+	LocationUUID string
+	ServerUUID   string
+}
+
+// NetworkCreateOpts параметры создания приватной сети
+type NetworkCreateOpts struct {
+	Name         string `json:"name"`
+	LocationUUID string `json:"location_uuid"`
+	Type         string `json:"type"`
+}
+
+// NetworkUpdateOpts параметры обновления сети
+type NetworkUpdateOpts struct {
+	Name string `json:"name,omitempty"`
+}
+
+// NetworkAttachment представляет подключение сервера к сети
+type NetworkAttachment struct {
+	ServerUUID  string `json:"server_uuid"`
+	NetworkUUID string `json:"network_uuid"`
+	Type        string `json:"type"` // public, private
+	VLAN        int    `json:"vlan,omitempty"`
+}
+
+// NetworkAttachOpts параметры подключения сервера к сети
+type NetworkAttachOpts struct {
+	NetworkUUID string `json:"network_uuid"`
+}
+
+// PriceOption представляет стоимость дополнительной опции сервера
+type PriceOption struct {
+	Type         string `json:"type"` // public_ip, private_network
+	LocationUUID string `json:"location_uuid,omitempty"`
+	Period       string `json:"period"`
+	Price        string `json:"price"`
+	Currency     string `json:"currency"`
+}
+
+const (
+	PriceOptionPublicIP       = "public_ip"
+	PriceOptionPrivateNetwork = "private_network"
+)
+
+// Availability представляет наличие серверов конфигурации в локации
+type Availability struct {
+	ConfigurationUUID string `json:"configuration_uuid"`
+	LocationUUID      string `json:"location_uuid"`
+	Available         int    `json:"available"`
+}
+
 // DedicatedServer основная структура сервера
 type DedicatedServer struct {
 	UUID              string                 `json:"uuid"`
@@ -131,12 +280,81 @@ type DedicatedServer struct {
 	OsParams          map[string]interface{} `json:"os_params,omitempty"`
 }
 
-// IPAddress структура IP адреса
+// Версии IP адресов
+const (
+	IPVersion4 = 4
+	IPVersion6 = 6
+)
+
+// IPAddress структура IP адреса. Для IPv6 адресов API возвращает
+// префикс вместо маски, для IPv4 может вернуть только маску.
 type IPAddress struct {
-	Type    string `json:"type"` // public, private
-	IP      string `json:"ip"`
-	Netmask string `json:"netmask"`
-	Gateway string `json:"gateway"`
+	Type         string `json:"type"` // public, private
+	IP           string `json:"ip"`
+	IPVersion    int    `json:"ip_version,omitempty"`
+	PrefixLength int    `json:"prefix_length,omitempty"`
+	Netmask      string `json:"netmask,omitempty"`
+	Gateway      string `json:"gateway"`
+}
+
+// Version возвращает версию адреса, определяя ее по самому адресу,
+// если API ее не вернул.
+func (a IPAddress) Version() int {
+	if a.IPVersion != 0 {
+		return a.IPVersion
+	}
+
+	ip := net.ParseIP(a.IP)
+	if ip == nil {
+		return 0
+	}
+	if ip.To4() != nil {
+		return IPVersion4
+	}
+
+	return IPVersion6
+}
+
+// Prefix возвращает длину префикса сети адреса, вычисляя ее по маске,
+// если API ее не вернул.
+func (a IPAddress) Prefix() int {
+	if a.PrefixLength != 0 {
+		return a.PrefixLength
+	}
+
+	mask := net.ParseIP(a.Netmask)
+	if mask == nil {
+		return 0
+	}
+	if mask4 := mask.To4(); mask4 != nil {
+		mask = mask4
+	}
+	ones, bits := net.IPMask(mask).Size()
+	if bits == 0 {
+		return 0
+	}
+
+	return ones
+}
+
+// CIDR возвращает сеть адреса в нотации CIDR или пустую строку,
+// если префикс неизвестен.
+func (a IPAddress) CIDR() string {
+	ip := net.ParseIP(a.IP)
+	prefix := a.Prefix()
+	if ip == nil || prefix == 0 {
+		return ""
+	}
+
+	bits := 32
+	if a.Version() == IPVersion6 {
+		bits = 128
+	} else {
+		ip = ip.To4()
+	}
+	network := &net.IPNet{IP: ip.Mask(net.CIDRMask(prefix, bits)), Mask: net.CIDRMask(prefix, bits)}
+
+	return network.String()
 }
 
 // DedicatedServerCreateOpts параметры создания сервера
@@ -154,16 +372,103 @@ type DedicatedServerCreateOpts struct {
 
 // DedicatedServerUpdateOpts параметры обновления сервера
 type DedicatedServerUpdateOpts struct {
-	OSImageUUID string                 `json:"os_image_uuid,omitempty"`
-	OsParams    map[string]interface{} `json:"os_params,omitempty"`
+	OSImageUUID        string                 `json:"os_image_uuid,omitempty"`
+	OsParams           map[string]interface{} `json:"os_params,omitempty"`
+	PreservePartitions []string               `json:"preserve_partitions,omitempty"`
+}
+
+// Partition представляет раздел диска установленной на сервере ОС
+type Partition struct {
+	Mount  string `json:"mount"`
+	Device string `json:"device"`
+	FSType string `json:"fs_type"`
+	SizeGB int    `json:"size_gb"`
+}
+
+// DedicatedServerTariffChangeOpts параметры смены тарифа сервера
+type DedicatedServerTariffChangeOpts struct {
+	TariffUUID string `json:"tariff_uuid"`
+}
+
+// Renewal информация о продлении сервера
+type Renewal struct {
+	PaidUntil time.Time `json:"paid_until"`
+	AutoRenew bool      `json:"auto_renew"`
+}
+
+// RenewalUpdateOpts параметры обновления продления сервера
+type RenewalUpdateOpts struct {
+	AutoRenew bool `json:"auto_renew"`
+}
+
+// ConsoleType тип удаленного доступа к серверу
+type ConsoleType string
+
+const (
+	ConsoleTypeKVM  ConsoleType = "kvm"
+	ConsoleTypeIPMI ConsoleType = "ipmi"
+)
+
+// ConsoleCreateOpts параметры открытия сессии удаленного доступа
+type ConsoleCreateOpts struct {
+	Type ConsoleType `json:"type"`
+}
+
+// Console сессия удаленного доступа к серверу (KVM или IPMI).
+// Для KVM заполняется URL, для IPMI - адрес и учетные данные.
+type Console struct {
+	Type      ConsoleType `json:"type"`
+	URL       string      `json:"url,omitempty"`
+	Host      string      `json:"host,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	Password  string      `json:"password,omitempty"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// TrafficStat статистика трафика. 95-й перцентиль считается
+// по скорости за период и поэтому не суммируется между адресами.
+type TrafficStat struct {
+	InboundBytes     int64   `json:"inbound_bytes"`
+	OutboundBytes    int64   `json:"outbound_bytes"`
+	Inbound95thMbps  float64 `json:"inbound_95th_mbps"`
+	Outbound95thMbps float64 `json:"outbound_95th_mbps"`
+}
+
+// IPTraffic статистика трафика одного IP адреса сервера
+type IPTraffic struct {
+	IP string `json:"ip"`
+	TrafficStat
+}
+
+// Traffic статистика публичного трафика сервера за период
+type Traffic struct {
+	ServerUUID string      `json:"server_uuid"`
+	From       time.Time   `json:"from"`
+	To         time.Time   `json:"to"`
+	Total      TrafficStat `json:"total"`
+	IPs        []IPTraffic `json:"ips,omitempty"`
+}
+
+// TrafficQueryParams параметры запроса статистики трафика,
+// время передается в формате RFC3339
+type TrafficQueryParams struct {
+	From string
+	To   string
+	IP   string
 }
 
 // DedicatedServerQueryParams параметры поиска серверов
 type DedicatedServerQueryParams struct {
-	UUID      string `json:"uuid,omitempty"`
-	ProjectID string `json:"project_id,omitempty"`
-	Name      string `json:"name,omitempty"`
-	Status    Status `json:"status,omitempty"`
+	UUID      string
+	ProjectID string
+	Name      string
+	Status    Status
+}
+
+// AvailabilityQueryParams параметры поиска наличия серверов
+type AvailabilityQueryParams struct {
+	LocationUUID      string
+	ConfigurationUUID string
 }
 
 const (
@@ -174,33 +479,21 @@ const (
 	TariffURI          = "/servers/v2/tariff"
 	OSImageURI         = "/servers/v2/boot/template/os/new"
 	NetworkURI         = "/servers/v2/network"
+	AvailabilityURI    = "/servers/v2/service/availability"
+	PriceOptionURI     = "/servers/v2/price/option"
+	IPSubnetURI        = "/servers/v2/ip/subnet"
 )
 
 // Методы для работы с серверами
-func (api *API) DedicatedServers(ctx context.Context, params *DedicatedServerQueryParams) ([]DedicatedServer, error) {
-	uri := DedicatedServerURI
-	if params != nil {
-		queryParams, err := setQueryParams(uri, params)
-		if err != nil {
-			return []DedicatedServer{}, err
-		}
-		uri = queryParams
-	}
-
-	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
-	if err != nil {
-		return []DedicatedServer{}, err
-	}
 
-	var result struct {
-		Result []DedicatedServer `json:"result"`
-	}
-	err = json.Unmarshal(resp, &result)
-	if err != nil {
-		return []DedicatedServer{}, fmt.Errorf("error during Unmarshal: %w", err)
-	}
+// DedicatedServersIter возвращает итератор по всем серверам, страницы
+// запрашиваются по мере обхода.
+func (api *API) DedicatedServersIter(ctx context.Context, params *DedicatedServerQueryParams) iter.Seq2[DedicatedServer, error] {
+	return paginate[DedicatedServer](ctx, api, DedicatedServerURI, params)
+}
 
-	return result.Result, nil
+func (api *API) DedicatedServers(ctx context.Context, params *DedicatedServerQueryParams) ([]DedicatedServer, error) {
+	return collect(api.DedicatedServersIter(ctx, params))
 }
 
 func (api *API) DedicatedServer(ctx context.Context, serverUUID string) (DedicatedServer, error) {
@@ -275,6 +568,160 @@ func (api *API) DeleteDedicatedServer(ctx context.Context, serverUUID string) er
 	return err
 }
 
+// ChangeDedicatedServerTariff меняет тариф и период оплаты сервера без его пересоздания
+func (api *API) ChangeDedicatedServerTariff(ctx context.Context, serverUUID string, opts DedicatedServerTariffChangeOpts) (DedicatedServer, error) {
+	uri := fmt.Sprintf("%s/%s/tariff", DedicatedServerURI, serverUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return DedicatedServer{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPut, uri, requestBody)
+	if err != nil {
+		return DedicatedServer{}, err
+	}
+
+	var result struct {
+		Result DedicatedServer `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return DedicatedServer{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// DedicatedServerRenewal возвращает информацию о продлении сервера
+func (api *API) DedicatedServerRenewal(ctx context.Context, serverUUID string) (Renewal, error) {
+	uri := fmt.Sprintf("%s/%s/renewal", DedicatedServerURI, serverUUID)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return Renewal{}, err
+	}
+
+	var result struct {
+		Result Renewal `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Renewal{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// UpdateDedicatedServerRenewal включает или отключает автопродление сервера
+func (api *API) UpdateDedicatedServerRenewal(ctx context.Context, serverUUID string, opts RenewalUpdateOpts) (Renewal, error) {
+	uri := fmt.Sprintf("%s/%s/renewal", DedicatedServerURI, serverUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return Renewal{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPatch, uri, requestBody)
+	if err != nil {
+		return Renewal{}, err
+	}
+
+	var result struct {
+		Result Renewal `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Renewal{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// Методы для работы с удаленным доступом к серверам
+func (api *API) CreateDedicatedServerConsole(ctx context.Context, serverUUID string, opts ConsoleCreateOpts) (Console, error) {
+	uri := fmt.Sprintf("%s/%s/console", DedicatedServerURI, serverUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return Console{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPost, uri, requestBody)
+	if err != nil {
+		return Console{}, err
+	}
+
+	var result struct {
+		Result Console `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Console{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// Методы для работы с разделами дисков
+func (api *API) DedicatedServerPartitions(ctx context.Context, serverUUID string) ([]Partition, error) {
+	uri := fmt.Sprintf("%s/%s/partitions", DedicatedServerURI, serverUUID)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []Partition{}, err
+	}
+
+	var result struct {
+		Result []Partition `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return []Partition{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) DedicatedServerHardware(ctx context.Context, serverUUID string) (ServerHardware, error) {
+	uri := fmt.Sprintf("%s/%s/hardware", DedicatedServerURI, serverUUID)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return ServerHardware{}, err
+	}
+
+	var result struct {
+		Result ServerHardware `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return ServerHardware{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// Методы для работы со статистикой трафика
+func (api *API) DedicatedServerTraffic(ctx context.Context, serverUUID string, params *TrafficQueryParams) (Traffic, error) {
+	uri := withQuery(fmt.Sprintf("%s/%s/traffic", DedicatedServerURI, serverUUID), params)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return Traffic{}, err
+	}
+
+	var result struct {
+		Result Traffic `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Traffic{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
 // Методы для работы с локациями
 func (api *API) Locations(ctx context.Context) ([]Location, error) {
 	resp, err := api.makeRequest(ctx, http.MethodGet, LocationURI, nil)
@@ -309,26 +756,14 @@ func (api *API) Location(ctx context.Context, locationUUID string) (Location, er
 }
 
 // Методы для работы с конфигурациями
-func (api *API) Configurations(ctx context.Context, locationUUID string) ([]Configuration, error) {
-	uri := ConfigurationURI
-	if locationUUID != "" {
-		uri = fmt.Sprintf("%s?location_uuid=%s", ConfigurationURI, locationUUID)
-	}
-
-	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
-	if err != nil {
-		return []Configuration{}, err
-	}
 
-	var result struct {
-		Result []Configuration `json:"result"`
-	}
-	err = json.Unmarshal(resp, &result)
-	if err != nil {
-		return []Configuration{}, fmt.Errorf("error during Unmarshal: %w", err)
-	}
+// ConfigurationsIter возвращает итератор по всем конфигурациям локации.
+func (api *API) ConfigurationsIter(ctx context.Context, locationUUID string) iter.Seq2[Configuration, error] {
+	return paginate[Configuration](ctx, api, ConfigurationURI, catalogQueryParams{LocationUUID: locationUUID})
+}
 
-	return result.Result, nil
+func (api *API) Configurations(ctx context.Context, locationUUID string) ([]Configuration, error) {
+	return collect(api.ConfigurationsIter(ctx, locationUUID))
 }
 
 func (api *API) Configuration(ctx context.Context, configUUID string) (Configuration, error) {
@@ -347,26 +782,14 @@ func (api *API) Configuration(ctx context.Context, configUUID string) (Configura
 }
 
 // Методы для работы с тарифами
-func (api *API) Tariffs(ctx context.Context, configUUID string) ([]Tariff, error) {
-	uri := TariffURI
-	if configUUID != "" {
-		uri = fmt.Sprintf("%s?configuration_uuid=%s", TariffURI, configUUID)
-	}
 
-	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
-	if err != nil {
-		return []Tariff{}, err
-	}
-
-	var result struct {
-		Result []Tariff `json:"result"`
-	}
-	err = json.Unmarshal(resp, &result)
-	if err != nil {
-		return []Tariff{}, fmt.Errorf("error during Unmarshal: %w", err)
-	}
+// TariffsIter возвращает итератор по всем тарифам конфигурации.
+func (api *API) TariffsIter(ctx context.Context, configUUID string) iter.Seq2[Tariff, error] {
+	return paginate[Tariff](ctx, api, TariffURI, catalogQueryParams{ConfigurationUUID: configUUID})
+}
 
-	return result.Result, nil
+func (api *API) Tariffs(ctx context.Context, configUUID string) ([]Tariff, error) {
+	return collect(api.TariffsIter(ctx, configUUID))
 }
 
 func (api *API) Tariff(ctx context.Context, tariffUUID string) (Tariff, error) {
@@ -384,26 +807,79 @@ func (api *API) Tariff(ctx context.Context, tariffUUID string) (Tariff, error) {
 	return Tariff{}, fmt.Errorf("tariff with UUID %s not found", tariffUUID)
 }
 
-// Методы для работы с образами ОС
-func (api *API) OSImages(ctx context.Context, locationUUID, serviceUUID string) ([]OSImage, error) {
-	uri := fmt.Sprintf("%s?location_uuid=%s&service_uuid=%s", OSImageURI, locationUUID, serviceUUID)
+// PeriodMonths возвращает длительность периода оплаты в месяцах
+func PeriodMonths(period string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case "day", "1 day", "daily":
+		return 12.0 / 365, nil
+	case "month", "1 month", "monthly":
+		return 1, nil
+	case "quarter", "3 months", "quarterly":
+		return 3, nil
+	case "half_year", "6 months":
+		return 6, nil
+	case "year", "1 year", "12 months", "yearly", "annual":
+		return 12, nil
+	}
+
+	return 0, fmt.Errorf("unknown billing period %q", period)
+}
+
+// MonthlyPrice возвращает стоимость тарифа в пересчете на один месяц
+func (t Tariff) MonthlyPrice() (float64, error) {
+	return monthlyPrice(t.Price, t.Period)
+}
+
+// MonthlyPrice возвращает стоимость опции в пересчете на один месяц
+func (o PriceOption) MonthlyPrice() (float64, error) {
+	return monthlyPrice(o.Price, o.Period)
+}
+
+func monthlyPrice(price, period string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(price), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", price, err)
+	}
+
+	months, err := PeriodMonths(period)
+	if err != nil {
+		return 0, err
+	}
+
+	return value / months, nil
+}
+
+// Методы для работы с ценами дополнительных опций
+func (api *API) PriceOptions(ctx context.Context, locationUUID string) ([]PriceOption, error) {
+	uri := withQuery(PriceOptionURI, catalogQueryParams{LocationUUID: locationUUID})
 
 	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
 	if err != nil {
-		return []OSImage{}, err
+		return []PriceOption{}, err
 	}
 
 	var result struct {
-		Result []OSImage `json:"result"`
+		Result []PriceOption `json:"result"`
 	}
 	err = json.Unmarshal(resp, &result)
 	if err != nil {
-		return []OSImage{}, fmt.Errorf("error during Unmarshal: %w", err)
+		return []PriceOption{}, fmt.Errorf("error during Unmarshal: %w", err)
 	}
 
 	return result.Result, nil
 }
 
+// Методы для работы с образами ОС
+
+// OSImagesIter возвращает итератор по всем образам ОС, доступным для услуги в локации.
+func (api *API) OSImagesIter(ctx context.Context, locationUUID, serviceUUID string) iter.Seq2[OSImage, error] {
+	return paginate[OSImage](ctx, api, OSImageURI, catalogQueryParams{LocationUUID: locationUUID, ServiceUUID: serviceUUID})
+}
+
+func (api *API) OSImages(ctx context.Context, locationUUID, serviceUUID string) ([]OSImage, error) {
+	return collect(api.OSImagesIter(ctx, locationUUID, serviceUUID))
+}
+
 func (api *API) OSImage(ctx context.Context, osImageUUID, locationUUID, serviceUUID string) (*OSImage, error) {
 	images, err := api.OSImages(ctx, locationUUID, serviceUUID)
 	if err != nil {
@@ -421,10 +897,7 @@ func (api *API) OSImage(ctx context.Context, osImageUUID, locationUUID, serviceU
 
 // Методы для работы с сетями
 func (api *API) Networks(ctx context.Context, locationUUID string) ([]Network, error) {
-	uri := NetworkURI
-	if locationUUID != "" {
-		uri = fmt.Sprintf("%s?location_uuid=%s", NetworkURI, locationUUID)
-	}
+	uri := withQuery(NetworkURI, catalogQueryParams{LocationUUID: locationUUID})
 
 	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -457,6 +930,244 @@ func (api *API) Network(ctx context.Context, networkUUID string) (Network, error
 	return Network{}, fmt.Errorf("network with UUID %s not found", networkUUID)
 }
 
+func (api *API) CreateNetwork(ctx context.Context, opts NetworkCreateOpts) (Network, error) {
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return Network{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPost, NetworkURI, requestBody)
+	if err != nil {
+		return Network{}, err
+	}
+
+	var result struct {
+		Result Network `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Network{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) UpdateNetwork(ctx context.Context, networkUUID string, opts NetworkUpdateOpts) (Network, error) {
+	uri := fmt.Sprintf("%s/%s", NetworkURI, networkUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return Network{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPatch, uri, requestBody)
+	if err != nil {
+		return Network{}, err
+	}
+
+	var result struct {
+		Result Network `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return Network{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) DeleteNetwork(ctx context.Context, networkUUID string) error {
+	uri := fmt.Sprintf("%s/%s", NetworkURI, networkUUID)
+
+	_, err := api.makeRequest(ctx, http.MethodDelete, uri, nil)
+	return err
+}
+
+// Методы для работы с подключениями серверов к сетям
+func (api *API) NetworkAttachments(ctx context.Context, serverUUID string) ([]NetworkAttachment, error) {
+	uri := fmt.Sprintf("%s/%s/network", DedicatedServerURI, serverUUID)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []NetworkAttachment{}, err
+	}
+
+	var result struct {
+		Result []NetworkAttachment `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return []NetworkAttachment{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) AttachNetwork(ctx context.Context, serverUUID string, opts NetworkAttachOpts) (NetworkAttachment, error) {
+	uri := fmt.Sprintf("%s/%s/network", DedicatedServerURI, serverUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return NetworkAttachment{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPost, uri, requestBody)
+	if err != nil {
+		return NetworkAttachment{}, err
+	}
+
+	var result struct {
+		Result NetworkAttachment `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return NetworkAttachment{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) DetachNetwork(ctx context.Context, serverUUID, networkUUID string) error {
+	uri := fmt.Sprintf("%s/%s/network/%s", DedicatedServerURI, serverUUID, networkUUID)
+
+	_, err := api.makeRequest(ctx, http.MethodDelete, uri, nil)
+	return err
+}
+
+// Методы для работы с подсетями публичных IP адресов
+func (api *API) IPSubnets(ctx context.Context, params *IPSubnetQueryParams) ([]IPSubnet, error) {
+	uri := withQuery(IPSubnetURI, params)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []IPSubnet{}, err
+	}
+
+	var result struct {
+		Result []IPSubnet `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return []IPSubnet{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) IPSubnet(ctx context.Context, subnetUUID string) (IPSubnet, error) {
+	uri := fmt.Sprintf("%s/%s", IPSubnetURI, subnetUUID)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return IPSubnet{}, err
+	}
+
+	var result struct {
+		Result IPSubnet `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return IPSubnet{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// CreateIPSubnet заказывает подсеть публичных IP адресов
+func (api *API) CreateIPSubnet(ctx context.Context, opts IPSubnetCreateOpts) (IPSubnet, error) {
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return IPSubnet{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPost, IPSubnetURI, requestBody)
+	if err != nil {
+		return IPSubnet{}, err
+	}
+
+	var result struct {
+		Result IPSubnet `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return IPSubnet{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (api *API) UpdateIPSubnet(ctx context.Context, subnetUUID string, opts IPSubnetUpdateOpts) (IPSubnet, error) {
+	uri := fmt.Sprintf("%s/%s", IPSubnetURI, subnetUUID)
+
+	requestBody, err := json.Marshal(opts)
+	if err != nil {
+		return IPSubnet{}, fmt.Errorf("error marshalling params to JSON: %w", err)
+	}
+
+	resp, err := api.makeRequest(ctx, http.MethodPatch, uri, requestBody)
+	if err != nil {
+		return IPSubnet{}, err
+	}
+
+	var result struct {
+		Result IPSubnet `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return IPSubnet{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// DeleteIPSubnet освобождает подсеть публичных IP адресов
+func (api *API) DeleteIPSubnet(ctx context.Context, subnetUUID string) error {
+	uri := fmt.Sprintf("%s/%s", IPSubnetURI, subnetUUID)
+
+	_, err := api.makeRequest(ctx, http.MethodDelete, uri, nil)
+	return err
+}
+
+// Методы для работы с наличием серверов
+func (api *API) Availability(ctx context.Context, params *AvailabilityQueryParams) ([]Availability, error) {
+	uri := withQuery(AvailabilityURI, params)
+
+	resp, err := api.makeRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []Availability{}, err
+	}
+
+	var result struct {
+		Result []Availability `json:"result"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return []Availability{}, fmt.Errorf("error during Unmarshal: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// AvailableCount возвращает количество серверов конфигурации, доступных для заказа в локации
+func (api *API) AvailableCount(ctx context.Context, configUUID, locationUUID string) (int, error) {
+	availability, err := api.Availability(ctx, &AvailabilityQueryParams{
+		LocationUUID:      locationUUID,
+		ConfigurationUUID: configUUID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, item := range availability {
+		if item.ConfigurationUUID == configUUID && item.LocationUUID == locationUUID {
+			count += item.Available
+		}
+	}
+
+	return count, nil
+}
+
 // Вспомогательные методы
 func (api *API) makeRequest(ctx context.Context, method, uri string, params interface{}) ([]byte, error) {
 	jsonBody, err := handleParams(params)
@@ -551,33 +1262,6 @@ func handleStatusCode(statusCode int, body []byte, uri string) error {
 	return errBody
 }
 
-func setQueryParams(uri string, params interface{}) (string, error) {
-	v := url.Values{}
-
-	var queryParams map[string]interface{}
-	jsonParams, err := json.Marshal(params)
-	if err != nil {
-		return "", fmt.Errorf("error marshalling params to JSON: %w", err)
-	}
-
-	err = json.Unmarshal(jsonParams, &queryParams)
-	if err != nil {
-		return "", fmt.Errorf("error during Unmarshal: %w", err)
-	}
-
-	for key, value := range queryParams {
-		if value != nil && value != "" {
-			v.Set(key, fmt.Sprintf("%v", value))
-		}
-	}
-
-	if len(v) > 0 {
-		uri = uri + "?" + v.Encode()
-	}
-
-	return uri, nil
-}
-
 func convertFieldFromStringToType(fieldValue string) interface{} {
 	if val, err := strconv.Atoi(fieldValue); err == nil {
 		return val
@@ -619,3 +1303,65 @@ func (api *API) WaitForServerStatus(ctx context.Context, serverUUID string, targ
 		}
 	}
 }
+
+// serverStatusPollInterval интервал опроса статуса серверов
+var serverStatusPollInterval = 10 * time.Second
+
+// WaitForServersStatus ожидает определенного статуса у группы серверов проекта.
+// Статусы всех серверов проверяются одним запросом списка серверов на каждой
+// итерации. Для StatusDeleted отсутствие сервера в списке считается удалением.
+func (api *API) WaitForServersStatus(ctx context.Context, projectID string, serverUUIDs []string, targetStatus Status, timeout time.Duration) error {
+	pending := make(map[string]struct{}, len(serverUUIDs))
+	for _, serverUUID := range serverUUIDs {
+		pending[serverUUID] = struct{}{}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(serverStatusPollInterval)
+	defer ticker.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return fmt.Errorf("timeout waiting for %d servers to reach status %s", len(pending), targetStatus)
+		case <-ticker.C:
+			servers, err := api.DedicatedServers(ctx, &DedicatedServerQueryParams{
+				ProjectID: projectID,
+			})
+			if err != nil {
+				return fmt.Errorf("error checking servers status: %w", err)
+			}
+
+			found := make(map[string]struct{}, len(servers))
+			for _, server := range servers {
+				if _, ok := pending[server.UUID]; !ok {
+					continue
+				}
+				found[server.UUID] = struct{}{}
+
+				if server.Status == targetStatus {
+					delete(pending, server.UUID)
+					continue
+				}
+
+				if server.Status == StatusError && targetStatus != StatusDeleted {
+					return fmt.Errorf("server %s entered error state", server.UUID)
+				}
+			}
+
+			if targetStatus == StatusDeleted {
+				for serverUUID := range pending {
+					if _, ok := found[serverUUID]; !ok {
+						delete(pending, serverUUID)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
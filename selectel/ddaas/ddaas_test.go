@@ -0,0 +1,360 @@
+package ddaas
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAPI(t *testing.T, handler http.HandlerFunc) *API {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	api, err := New("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return api
+}
+
+func TestAvailableCount(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, AvailabilityURI, r.URL.Path)
+		assert.Equal(t, "loc-1", r.URL.Query().Get("location_uuid"))
+		assert.Equal(t, "conf-1", r.URL.Query().Get("configuration_uuid"))
+		assert.Equal(t, "token", r.Header.Get("X-Token"))
+
+		w.Write([]byte(`{"result": [
+			{"configuration_uuid": "conf-1", "location_uuid": "loc-1", "available": 2},
+			{"configuration_uuid": "conf-1", "location_uuid": "loc-1", "available": 3},
+			{"configuration_uuid": "conf-2", "location_uuid": "loc-1", "available": 7}
+		]}`))
+	})
+
+	count, err := api.AvailableCount(context.Background(), "conf-1", "loc-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestAvailableCountAPIError(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code": 403, "message": "forbidden"}`))
+	})
+
+	_, err := api.AvailableCount(context.Background(), "conf-1", "loc-1")
+
+	assert.EqualError(t, err, "API error 403: forbidden")
+}
+
+func TestTariffMonthlyPrice(t *testing.T) {
+	tableTests := []struct {
+		tariff   Tariff
+		expected float64
+	}{
+		{
+			tariff:   Tariff{Price: "9000", Period: "month"},
+			expected: 9000,
+		},
+		{
+			tariff:   Tariff{Price: "25500.00", Period: "3 months"},
+			expected: 8500,
+		},
+		{
+			tariff:   Tariff{Price: "96000", Period: "Year"},
+			expected: 8000,
+		},
+		{
+			tariff:   Tariff{Price: " 4500.5 ", Period: "half_year"},
+			expected: 750.0833333333334,
+		},
+	}
+
+	for _, test := range tableTests {
+		actual, err := test.tariff.MonthlyPrice()
+
+		assert.NoError(t, err)
+		assert.InDelta(t, test.expected, actual, 1e-9)
+	}
+}
+
+func TestTariffMonthlyPriceInvalid(t *testing.T) {
+	_, err := Tariff{Price: "free", Period: "month"}.MonthlyPrice()
+	assert.Error(t, err)
+
+	_, err = Tariff{Price: "100", Period: "fortnight"}.MonthlyPrice()
+	assert.EqualError(t, err, `unknown billing period "fortnight"`)
+}
+
+func TestChangeDedicatedServerTariff(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, DedicatedServerURI+"/srv-1/tariff", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"tariff_uuid": "yearly"}`, string(body))
+
+		w.Write([]byte(`{"result": {"uuid": "srv-1", "tariff_uuid": "yearly"}}`))
+	})
+
+	server, err := api.ChangeDedicatedServerTariff(context.Background(), "srv-1", DedicatedServerTariffChangeOpts{
+		TariffUUID: "yearly",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "yearly", server.TariffUUID)
+}
+
+func TestCreateNetwork(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, NetworkURI, r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name": "nodes", "location_uuid": "loc-1", "type": "private"}`, string(body))
+
+		w.Write([]byte(`{"result": {"uuid": "net-1", "name": "nodes", "type": "private", "location_uuid": "loc-1", "vlan": 1042}}`))
+	})
+
+	network, err := api.CreateNetwork(context.Background(), NetworkCreateOpts{
+		Name:         "nodes",
+		LocationUUID: "loc-1",
+		Type:         "private",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Network{UUID: "net-1", Name: "nodes", Type: "private", LocationUUID: "loc-1", VLAN: 1042}, network)
+}
+
+func TestCreateDedicatedServerConsole(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, DedicatedServerURI+"/srv-1/console", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"type": "ipmi"}`, string(body))
+
+		w.Write([]byte(`{"result": {"type": "ipmi", "host": "10.0.0.5", "username": "admin", "password": "secret", "expires_at": "2024-01-01T12:00:00Z"}}`))
+	})
+
+	console, err := api.CreateDedicatedServerConsole(context.Background(), "srv-1", ConsoleCreateOpts{
+		Type: ConsoleTypeIPMI,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", console.Host)
+	assert.Equal(t, "admin", console.Username)
+	assert.Equal(t, "secret", console.Password)
+	assert.Equal(t, "2024-01-01T12:00:00Z", console.ExpiresAt.Format(time.RFC3339))
+}
+
+func TestOSImageParameterSchema(t *testing.T) {
+	image := OSImage{
+		Parameters: map[string]string{
+			"password":  "string, required",
+			"user_data": "string",
+			"raid":      "bool",
+			"hostname":  "",
+			"ssh_key":   "Boolean: enables soft RAID",
+		},
+	}
+
+	parameters, unrecognized := image.ParameterSchema()
+
+	assert.Equal(t, map[string]OSParameter{
+		"password":  {Name: "password", Type: OSParameterTypeString, Required: true},
+		"user_data": {Name: "user_data", Type: OSParameterTypeString},
+		"raid":      {Name: "raid", Type: OSParameterTypeBool},
+	}, parameters)
+	assert.Equal(t, []string{"hostname", "ssh_key"}, unrecognized)
+}
+
+func TestWaitForServersStatus(t *testing.T) {
+	serverStatusPollInterval = time.Millisecond
+	t.Cleanup(func() { serverStatusPollInterval = 10 * time.Second })
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, DedicatedServerURI, r.URL.Path)
+		assert.Equal(t, "project-1", r.URL.Query().Get("project_id"))
+
+		requests++
+		if requests == 1 {
+			w.Write([]byte(`{"result": [{"uuid": "srv-1", "status": "ACTIVE"}, {"uuid": "srv-2", "status": "BUILDING"}]}`))
+			return
+		}
+		w.Write([]byte(`{"result": [{"uuid": "srv-1", "status": "ACTIVE"}, {"uuid": "srv-2", "status": "ACTIVE"}]}`))
+	})
+
+	err := api.WaitForServersStatus(context.Background(), "project-1", []string{"srv-1", "srv-2"}, StatusActive, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestWaitForServersStatusDeleted(t *testing.T) {
+	serverStatusPollInterval = time.Millisecond
+	t.Cleanup(func() { serverStatusPollInterval = 10 * time.Second })
+
+	api := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"result": [{"uuid": "srv-3", "status": "ACTIVE"}]}`))
+	})
+
+	err := api.WaitForServersStatus(context.Background(), "project-1", []string{"srv-1", "srv-2"}, StatusDeleted, time.Minute)
+
+	assert.NoError(t, err)
+}
+
+func TestWaitForServersStatusError(t *testing.T) {
+	serverStatusPollInterval = time.Millisecond
+	t.Cleanup(func() { serverStatusPollInterval = 10 * time.Second })
+
+	api := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"result": [{"uuid": "srv-1", "status": "ERROR"}]}`))
+	})
+
+	err := api.WaitForServersStatus(context.Background(), "project-1", []string{"srv-1"}, StatusActive, time.Minute)
+
+	assert.EqualError(t, err, "server srv-1 entered error state")
+}
+
+func TestDedicatedServerTraffic(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, DedicatedServerURI+"/srv-1/traffic", r.URL.Path)
+		assert.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("from"))
+		assert.Equal(t, "2024-02-01T00:00:00Z", r.URL.Query().Get("to"))
+		assert.False(t, r.URL.Query().Has("ip"))
+
+		w.Write([]byte(`{"result": {
+			"server_uuid": "srv-1",
+			"total": {"inbound_bytes": 300, "outbound_bytes": 500, "inbound_95th_mbps": 12.5, "outbound_95th_mbps": 40},
+			"ips": [{"ip": "203.0.113.10", "inbound_bytes": 300, "outbound_bytes": 500, "inbound_95th_mbps": 12.5, "outbound_95th_mbps": 40}]
+		}}`))
+	})
+
+	traffic, err := api.DedicatedServerTraffic(context.Background(), "srv-1", &TrafficQueryParams{
+		From: "2024-01-01T00:00:00Z",
+		To:   "2024-02-01T00:00:00Z",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), traffic.Total.OutboundBytes)
+	assert.Equal(t, 40.0, traffic.Total.Outbound95thMbps)
+	assert.Len(t, traffic.IPs, 1)
+	assert.Equal(t, "203.0.113.10", traffic.IPs[0].IP)
+	assert.Equal(t, int64(300), traffic.IPs[0].InboundBytes)
+}
+
+func TestIPAddressFamily(t *testing.T) {
+	ipv4 := IPAddress{IP: "203.0.113.10", Netmask: "255.255.255.0"}
+	assert.Equal(t, IPVersion4, ipv4.Version())
+	assert.Equal(t, 24, ipv4.Prefix())
+	assert.Equal(t, "203.0.113.0/24", ipv4.CIDR())
+
+	ipv6 := IPAddress{IP: "2001:db8::10", PrefixLength: 64}
+	assert.Equal(t, IPVersion6, ipv6.Version())
+	assert.Equal(t, 64, ipv6.Prefix())
+	assert.Equal(t, "2001:db8::/64", ipv6.CIDR())
+
+	unknown := IPAddress{IP: "203.0.113.10"}
+	assert.Equal(t, 0, unknown.Prefix())
+	assert.Equal(t, "", unknown.CIDR())
+}
+
+func TestIPSubnetIPv6Addresses(t *testing.T) {
+	subnet := IPSubnet{
+		IPVersion:    IPVersion6,
+		PrefixLength: 64,
+		Gateway:      "2001:db8::1",
+		Addresses:    []string{"2001:db8::2"},
+	}
+
+	assert.Equal(t, []IPAddress{
+		{Type: "public", IP: "2001:db8::2", IPVersion: IPVersion6, PrefixLength: 64, Gateway: "2001:db8::1"},
+	}, subnet.IPAddresses())
+}
+
+func TestDedicatedServerPartitions(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, DedicatedServerURI+"/srv-1/partitions", r.URL.Path)
+
+		w.Write([]byte(`{"result": [
+			{"mount": "/", "device": "/dev/md0", "fs_type": "ext4", "size_gb": 100},
+			{"mount": "/data", "device": "/dev/md2", "fs_type": "xfs", "size_gb": 3600}
+		]}`))
+	})
+
+	partitions, err := api.DedicatedServerPartitions(context.Background(), "srv-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Partition{
+		{Mount: "/", Device: "/dev/md0", FSType: "ext4", SizeGB: 100},
+		{Mount: "/data", Device: "/dev/md2", FSType: "xfs", SizeGB: 3600},
+	}, partitions)
+}
+
+func TestDedicatedServerHardware(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, DedicatedServerURI+"/srv-1/hardware", r.URL.Path)
+
+		w.Write([]byte(`{"result": {
+			"health": "degraded",
+			"cpus": [
+				{"model": "Intel Xeon Gold 6240", "cores": 18, "frequency_ghz": 2.6, "health": "ok"},
+				{"model": "Intel Xeon Gold 6240", "cores": 18, "frequency_ghz": 2.6, "health": "ok"}
+			],
+			"memory": [
+				{"size_gb": 32, "health": "ok"},
+				{"size_gb": 32, "health": "ok"}
+			],
+			"disks": [
+				{"type": "SSD NVMe", "model": "Samsung PM983", "serial_number": "S1", "size_gb": 960, "health": "ok"},
+				{"type": "HDD", "model": "Toshiba MG04", "serial_number": "S2", "size_gb": 4000, "health": "failed"}
+			]
+		}}`))
+	})
+
+	serverHardware, err := api.DedicatedServerHardware(context.Background(), "srv-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Hardware{
+		CPU:   CPU{Model: "Intel Xeon Gold 6240", Sockets: 2, Cores: 36, FrequencyGHz: 2.6},
+		RAMGB: 64,
+		Disks: []Disk{
+			{Type: DiskTypeNVMe, SizeGB: 960, Model: "Samsung PM983", SerialNumber: "S1", Health: "ok"},
+			{Type: DiskTypeHDD, SizeGB: 4000, Model: "Toshiba MG04", SerialNumber: "S2", Health: "failed"},
+		},
+		Health: "degraded",
+	}, serverHardware.Hardware())
+}
+
+func TestUpdateDedicatedServerPreservePartitions(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.JSONEq(t, `{"os_image_uuid": "os-1", "preserve_partitions": ["/data"]}`, string(body))
+
+		w.Write([]byte(`{"result": {"uuid": "srv-1", "status": "REINSTALLING"}}`))
+	})
+
+	_, err := api.UpdateDedicatedServer(context.Background(), "srv-1", DedicatedServerUpdateOpts{
+		OSImageUUID:        "os-1",
+		PreservePartitions: []string{"/data"},
+	})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,265 @@
+package ddaas
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Типы дисков
+const (
+	DiskTypeHDD  = "HDD"
+	DiskTypeSSD  = "SSD"
+	DiskTypeNVMe = "NVMe"
+)
+
+// CPU структурированное описание процессоров конфигурации
+type CPU struct {
+	Model        string
+	Sockets      int
+	Cores        int
+	FrequencyGHz float64
+}
+
+// Disk описание одного установленного диска. Модель, серийный номер
+// и состояние известны только для установленных в сервер дисков.
+type Disk struct {
+	Type         string
+	SizeGB       int
+	Model        string
+	SerialNumber string
+	Health       string
+}
+
+// Hardware структурированное описание конфигурации сервера
+type Hardware struct {
+	CPU    CPU
+	RAMGB  int
+	Disks  []Disk
+	Health string
+}
+
+// ServerHardware компоненты, фактически установленные в сервер, и их состояние
+type ServerHardware struct {
+	Health string               `json:"health"`
+	CPUs   []ServerHardwareCPU  `json:"cpus"`
+	Memory []ServerHardwareRAM  `json:"memory"`
+	Disks  []ServerHardwareDisk `json:"disks"`
+}
+
+// ServerHardwareCPU установленный процессор
+type ServerHardwareCPU struct {
+	Model        string  `json:"model"`
+	Cores        int     `json:"cores"`
+	FrequencyGHz float64 `json:"frequency_ghz"`
+	Health       string  `json:"health"`
+}
+
+// ServerHardwareRAM установленный модуль памяти
+type ServerHardwareRAM struct {
+	SizeGB int    `json:"size_gb"`
+	Health string `json:"health"`
+}
+
+// ServerHardwareDisk установленный диск
+type ServerHardwareDisk struct {
+	Type         string `json:"type"`
+	Model        string `json:"model"`
+	SerialNumber string `json:"serial_number"`
+	SizeGB       int    `json:"size_gb"`
+	Health       string `json:"health"`
+}
+
+// Hardware сводит установленные компоненты сервера к описанию конфигурации.
+func (h ServerHardware) Hardware() Hardware {
+	hardware := Hardware{
+		CPU:    CPU{Sockets: len(h.CPUs)},
+		Health: h.Health,
+	}
+
+	for i, cpu := range h.CPUs {
+		if i == 0 {
+			hardware.CPU.Model = cpu.Model
+			hardware.CPU.FrequencyGHz = cpu.FrequencyGHz
+		}
+		hardware.CPU.Cores += cpu.Cores
+	}
+
+	for _, module := range h.Memory {
+		hardware.RAMGB += module.SizeGB
+	}
+
+	for _, disk := range h.Disks {
+		hardware.Disks = append(hardware.Disks, Disk{
+			Type:         parseDiskType(disk.Type),
+			SizeGB:       disk.SizeGB,
+			Model:        disk.Model,
+			SerialNumber: disk.SerialNumber,
+			Health:       disk.Health,
+		})
+	}
+
+	return hardware
+}
+
+var (
+	hardwareCountRe     = regexp.MustCompile(`^\s*(\d+)\s*[xXхХ×]\s*`)
+	hardwareFrequencyRe = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(?:GHz|ГГц)`)
+	hardwareCoresRe     = regexp.MustCompile(`(?i)(\d+)\s*-?\s*(?:cores?|ядер|ядра|ядро)`)
+	hardwareSizeRe      = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(GB|ГБ|TB|ТБ)`)
+	hardwareStorageSep  = regexp.MustCompile(`\+|;|,\s`)
+)
+
+// Hardware разбирает текстовые поля CPU, RAM и Storage конфигурации.
+func (c Configuration) Hardware() (Hardware, error) {
+	cpu, err := ParseCPU(c.CPU)
+	if err != nil {
+		return Hardware{}, err
+	}
+
+	ramGB, err := ParseRAM(c.RAM)
+	if err != nil {
+		return Hardware{}, err
+	}
+
+	disks, err := ParseStorage(c.Storage)
+	if err != nil {
+		return Hardware{}, err
+	}
+
+	return Hardware{
+		CPU:   cpu,
+		RAMGB: ramGB,
+		Disks: disks,
+	}, nil
+}
+
+// ParseCPU разбирает описание процессора вида "2 x Intel Xeon Gold 6240 2.6 GHz 18 cores".
+// Количество ядер возвращается суммарно по всем сокетам.
+func ParseCPU(value string) (CPU, error) {
+	cpu := CPU{Sockets: 1}
+
+	rest := value
+	if match := hardwareCountRe.FindStringSubmatch(rest); match != nil {
+		cpu.Sockets, _ = strconv.Atoi(match[1])
+		rest = rest[len(match[0]):]
+	}
+
+	model := rest
+	if loc := hardwareFrequencyRe.FindStringSubmatchIndex(rest); loc != nil {
+		frequency, err := parseHardwareFloat(rest[loc[2]:loc[3]])
+		if err != nil {
+			return CPU{}, fmt.Errorf("unable to parse CPU frequency in %q: %w", value, err)
+		}
+		cpu.FrequencyGHz = frequency
+		model = rest[:loc[0]]
+	}
+
+	if match := hardwareCoresRe.FindStringSubmatch(rest); match != nil {
+		cores, _ := strconv.Atoi(match[1])
+		cpu.Cores = cores * cpu.Sockets
+	}
+
+	if idx := strings.IndexAny(model, "(,"); idx >= 0 {
+		model = model[:idx]
+	}
+	cpu.Model = strings.TrimSpace(model)
+
+	if cpu.Model == "" {
+		return CPU{}, fmt.Errorf("unable to parse CPU model in %q", value)
+	}
+
+	return cpu, nil
+}
+
+// ParseRAM разбирает объем памяти вида "64 GB DDR4" и возвращает его в GB.
+func ParseRAM(value string) (int, error) {
+	match := hardwareSizeRe.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("unable to parse RAM size in %q", value)
+	}
+
+	size, err := parseHardwareFloat(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse RAM size in %q: %w", value, err)
+	}
+	if isTerabytes(match[2]) {
+		size *= 1024
+	}
+
+	return int(math.Round(size)), nil
+}
+
+// ParseStorage разбирает описание дисков вида "2 x 960 GB SSD NVMe + 2 x 4 TB HDD SATA".
+// Каждый диск возвращается отдельным элементом, объем дисков в TB
+// переводится в GB по десятичной системе, как указывают производители.
+func ParseStorage(value string) ([]Disk, error) {
+	var disks []Disk
+
+	for _, part := range hardwareStorageSep.Split(value, -1) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		count := 1
+		rest := part
+		if match := hardwareCountRe.FindStringSubmatch(rest); match != nil {
+			count, _ = strconv.Atoi(match[1])
+			rest = rest[len(match[0]):]
+		}
+
+		match := hardwareSizeRe.FindStringSubmatchIndex(rest)
+		if match == nil {
+			return nil, fmt.Errorf("unable to parse disk size in %q", strings.TrimSpace(part))
+		}
+
+		size, err := parseHardwareFloat(rest[match[2]:match[3]])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse disk size in %q: %w", strings.TrimSpace(part), err)
+		}
+		if isTerabytes(rest[match[4]:match[5]]) {
+			size *= 1000
+		}
+
+		disk := Disk{
+			Type:   parseDiskType(rest[match[1]:]),
+			SizeGB: int(math.Round(size)),
+		}
+		for i := 0; i < count; i++ {
+			disks = append(disks, disk)
+		}
+	}
+
+	if len(disks) == 0 {
+		return nil, fmt.Errorf("unable to parse storage in %q", value)
+	}
+
+	return disks, nil
+}
+
+func parseDiskType(value string) string {
+	value = strings.ToUpper(value)
+
+	switch {
+	case strings.Contains(value, "NVME"):
+		return DiskTypeNVMe
+	case strings.Contains(value, "SSD"):
+		return DiskTypeSSD
+	case strings.Contains(value, "HDD"):
+		return DiskTypeHDD
+	default:
+		return ""
+	}
+}
+
+func isTerabytes(unit string) bool {
+	unit = strings.ToUpper(unit)
+
+	return unit == "TB" || unit == "ТБ"
+}
+
+func parseHardwareFloat(value string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+}
@@ -0,0 +1,78 @@
+package ddaas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPU(t *testing.T) {
+	cpu, err := ParseCPU("2 x Intel Xeon Gold 6240 2.6 GHz 18 cores")
+
+	assert.NoError(t, err)
+	assert.Equal(t, CPU{Model: "Intel Xeon Gold 6240", Sockets: 2, Cores: 36, FrequencyGHz: 2.6}, cpu)
+}
+
+func TestParseCPUWithoutSockets(t *testing.T) {
+	cpu, err := ParseCPU("Intel Xeon E-2236 3,4 ГГц (6 ядер)")
+
+	assert.NoError(t, err)
+	assert.Equal(t, CPU{Model: "Intel Xeon E-2236", Sockets: 1, Cores: 6, FrequencyGHz: 3.4}, cpu)
+}
+
+func TestParseCPUInvalid(t *testing.T) {
+	_, err := ParseCPU("")
+
+	assert.EqualError(t, err, `unable to parse CPU model in ""`)
+}
+
+func TestParseRAM(t *testing.T) {
+	for value, expected := range map[string]int{
+		"64 GB DDR4": 64,
+		"128 ГБ":     128,
+		"1 TB DDR4":  1024,
+		"256GB DDR5": 256,
+	} {
+		ramGB, err := ParseRAM(value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ramGB, value)
+	}
+
+	_, err := ParseRAM("n/a")
+	assert.EqualError(t, err, `unable to parse RAM size in "n/a"`)
+}
+
+func TestParseStorage(t *testing.T) {
+	disks, err := ParseStorage("2 x 960 GB SSD NVMe + 1,92 TB SSD SATA, 2 x 4 TB HDD SATA")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Disk{
+		{Type: DiskTypeNVMe, SizeGB: 960},
+		{Type: DiskTypeNVMe, SizeGB: 960},
+		{Type: DiskTypeSSD, SizeGB: 1920},
+		{Type: DiskTypeHDD, SizeGB: 4000},
+		{Type: DiskTypeHDD, SizeGB: 4000},
+	}, disks)
+}
+
+func TestParseStorageInvalid(t *testing.T) {
+	_, err := ParseStorage("2 x SSD")
+
+	assert.EqualError(t, err, `unable to parse disk size in "2 x SSD"`)
+}
+
+func TestConfigurationHardware(t *testing.T) {
+	configuration := Configuration{
+		CPU:     "Intel Xeon E-2236 3.4 GHz 6 cores",
+		RAM:     "32 GB",
+		Storage: "2 x 480 GB SSD",
+	}
+
+	hardware, err := configuration.Hardware()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, hardware.CPU.Cores)
+	assert.Equal(t, 32, hardware.RAMGB)
+	assert.Len(t, hardware.Disks, 2)
+}
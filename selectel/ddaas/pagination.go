@@ -0,0 +1,123 @@
+package ddaas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// pageLimit количество элементов, запрашиваемых за один запрос списка
+var pageLimit = 100
+
+// pageQueryParams добавляет к параметрам запроса limit и offset страницы
+type pageQueryParams struct {
+	params queryParams
+	limit  int
+	offset int
+}
+
+func (p pageQueryParams) queryValues() url.Values {
+	values := p.params.queryValues()
+	values.Set("limit", strconv.Itoa(p.limit))
+	values.Set("offset", strconv.Itoa(p.offset))
+
+	return values
+}
+
+// Pagination описание страницы списка в ответе API
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+// paginate возвращает итератор по всем элементам списка, запрашивая
+// страницы по мере обхода. Если в ответе нет блока pagination, API не
+// поддерживает пагинацию и ответ считается полным списком. Обход
+// заканчивается, когда получено total элементов, а без total — на странице
+// короче limit из ответа. Обход также
+// прекращается, если API игнорирует offset и повторяет предыдущую страницу.
+func paginate[T any](ctx context.Context, api *API, uri string, params queryParams) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		offset := 0
+		var previousFirst []byte
+		for {
+			pageURI := withQuery(uri, pageQueryParams{params: params, limit: pageLimit, offset: offset})
+			resp, err := api.makeRequest(ctx, http.MethodGet, pageURI, nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var result struct {
+				Result     []json.RawMessage `json:"result"`
+				Pagination *Pagination       `json:"pagination"`
+			}
+			err = json.Unmarshal(resp, &result)
+			if err != nil {
+				yield(zero, fmt.Errorf("error during Unmarshal: %w", err))
+				return
+			}
+
+			if len(result.Result) == 0 {
+				return
+			}
+			if previousFirst != nil && bytes.Equal(previousFirst, result.Result[0]) {
+				return
+			}
+			previousFirst = result.Result[0]
+
+			for _, raw := range result.Result {
+				var item T
+				if err := json.Unmarshal(raw, &item); err != nil {
+					yield(zero, fmt.Errorf("error during Unmarshal: %w", err))
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if result.Pagination == nil {
+				return
+			}
+			offset += len(result.Result)
+			if result.Pagination.Total > 0 {
+				if offset >= result.Pagination.Total {
+					return
+				}
+				continue
+			}
+
+			// API может ограничивать размер страницы сильнее запрошенного,
+			// поэтому последняя страница определяется по limit из ответа
+			limit := result.Pagination.Limit
+			if limit <= 0 {
+				limit = pageLimit
+			}
+			if len(result.Result) < limit {
+				return
+			}
+		}
+	}
+}
+
+// collect собирает все элементы итератора в срез
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return []T{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
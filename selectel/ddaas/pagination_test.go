@@ -0,0 +1,202 @@
+package ddaas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setTestPageLimit(t *testing.T, limit int) {
+	t.Helper()
+
+	pageLimit = limit
+	t.Cleanup(func() { pageLimit = 100 })
+}
+
+func testServersPage(offset, limit, total int) string {
+	items := make([]string, 0, limit)
+	for i := offset; i < offset+limit && i < total; i++ {
+		items = append(items, fmt.Sprintf(`{"uuid": "srv-%d"}`, i))
+	}
+
+	return fmt.Sprintf(`{"result": [%s], "pagination": {"limit": %d, "offset": %d, "total": %d}}`,
+		strings.Join(items, ","), limit, offset, total)
+}
+
+func TestDedicatedServersPagination(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	var offsets []string
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "project-1", r.URL.Query().Get("project_id"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Write([]byte(testServersPage(offset, 2, 5)))
+	})
+
+	servers, err := api.DedicatedServers(context.Background(), &DedicatedServerQueryParams{ProjectID: "project-1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, servers, 5)
+	assert.Equal(t, "srv-4", servers[4].UUID)
+	assert.Equal(t, []string{"0", "2", "4"}, offsets)
+}
+
+func TestDedicatedServersPaginationStopsAtTotal(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Write([]byte(testServersPage(offset, 2, 4)))
+	})
+
+	servers, err := api.DedicatedServers(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, servers, 4)
+	assert.Equal(t, 2, requests)
+}
+
+func TestDedicatedServersPaginationCappedPageSize(t *testing.T) {
+	setTestPageLimit(t, 5)
+
+	var offsets []string
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5", r.URL.Query().Get("limit"))
+
+		// Сервер отдает не больше 2 элементов независимо от запрошенного limit
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Write([]byte(testServersPage(offset, 2, 5)))
+	})
+
+	servers, err := api.DedicatedServers(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, servers, 5)
+	assert.Equal(t, []string{"0", "2", "4"}, offsets)
+}
+
+func TestDedicatedServersPaginationCappedPageSizeWithoutTotal(t *testing.T) {
+	setTestPageLimit(t, 5)
+
+	var offsets []string
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := strings.Replace(testServersPage(offset, 2, 5), `, "total": 5`, "", 1)
+		w.Write([]byte(page))
+	})
+
+	servers, err := api.DedicatedServers(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, servers, 5)
+	assert.Equal(t, []string{"0", "2", "4"}, offsets)
+}
+
+func TestConfigurationsWithoutPaginationSupport(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "loc-1", r.URL.Query().Get("location_uuid"))
+
+		requests++
+		w.Write([]byte(`{"result": [{"uuid": "conf-1"}, {"uuid": "conf-2"}, {"uuid": "conf-3"}]}`))
+	})
+
+	configurations, err := api.Configurations(context.Background(), "loc-1")
+
+	assert.NoError(t, err)
+	assert.Len(t, configurations, 3)
+	assert.Equal(t, 1, requests)
+}
+
+func TestConfigurationsWithoutPaginationBlockStopsAtFirstPage(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"result": [{"uuid": "conf-1"}, {"uuid": "conf-2"}]}`))
+	})
+
+	configurations, err := api.Configurations(context.Background(), "loc-1")
+
+	assert.NoError(t, err)
+	assert.Len(t, configurations, 2)
+	assert.Equal(t, 1, requests)
+}
+
+func TestDedicatedServersPaginationIgnoredOffset(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Сервер игнорирует offset и всегда возвращает первую страницу
+		w.Write([]byte(`{"result": [{"uuid": "srv-0"}, {"uuid": "srv-1"}], "pagination": {"limit": 2, "offset": 0}}`))
+	})
+
+	servers, err := api.DedicatedServers(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+	assert.Equal(t, 2, requests)
+}
+
+func TestDedicatedServersIterStopsEarly(t *testing.T) {
+	setTestPageLimit(t, 2)
+
+	requests := 0
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Write([]byte(testServersPage(offset, 2, 10)))
+	})
+
+	var found DedicatedServer
+	for server, err := range api.DedicatedServersIter(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if server.UUID == "srv-2" {
+			found = server
+			break
+		}
+	}
+
+	assert.Equal(t, "srv-2", found.UUID)
+	assert.Equal(t, 2, requests)
+}
+
+func TestOSImagesPaginationError(t *testing.T) {
+	setTestPageLimit(t, 1)
+
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "loc-1", r.URL.Query().Get("location_uuid"))
+		assert.Equal(t, "svc-1", r.URL.Query().Get("service_uuid"))
+
+		if r.URL.Query().Get("offset") == "1" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code": 403, "message": "forbidden"}`))
+			return
+		}
+		w.Write([]byte(`{"result": [{"uuid": "os-1"}], "pagination": {"limit": 1, "offset": 0, "total": 2}}`))
+	})
+
+	images, err := api.OSImages(context.Background(), "loc-1", "svc-1")
+
+	assert.EqualError(t, err, "API error 403: forbidden")
+	assert.Empty(t, images)
+}
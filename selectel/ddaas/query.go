@@ -0,0 +1,87 @@
+package ddaas
+
+import (
+	"net/url"
+)
+
+// queryParams параметры запроса, которые передаются в строке запроса
+type queryParams interface {
+	queryValues() url.Values
+}
+
+// withQuery добавляет к URI параметры запроса, параметры без значений не передаются
+func withQuery(uri string, params queryParams) string {
+	values := params.queryValues()
+	for key, value := range values {
+		if len(value) == 0 || value[0] == "" {
+			values.Del(key)
+		}
+	}
+	if len(values) == 0 {
+		return uri
+	}
+
+	return uri + "?" + values.Encode()
+}
+
+// catalogQueryParams параметры фильтрации каталога по локации, конфигурации и услуге
+type catalogQueryParams struct {
+	LocationUUID      string
+	ConfigurationUUID string
+	ServiceUUID       string
+}
+
+func (p catalogQueryParams) queryValues() url.Values {
+	return url.Values{
+		"location_uuid":      {p.LocationUUID},
+		"configuration_uuid": {p.ConfigurationUUID},
+		"service_uuid":       {p.ServiceUUID},
+	}
+}
+
+func (p *DedicatedServerQueryParams) queryValues() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	return url.Values{
+		"uuid":       {p.UUID},
+		"project_id": {p.ProjectID},
+		"name":       {p.Name},
+		"status":     {string(p.Status)},
+	}
+}
+
+func (p *TrafficQueryParams) queryValues() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	return url.Values{
+		"from": {p.From},
+		"to":   {p.To},
+		"ip":   {p.IP},
+	}
+}
+
+func (p *IPSubnetQueryParams) queryValues() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	return url.Values{
+		"location_uuid": {p.LocationUUID},
+		"server_uuid":   {p.ServerUUID},
+	}
+}
+
+func (p *AvailabilityQueryParams) queryValues() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	return url.Values{
+		"location_uuid":      {p.LocationUUID},
+		"configuration_uuid": {p.ConfigurationUUID},
+	}
+}
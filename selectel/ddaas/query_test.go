@@ -0,0 +1,57 @@
+package ddaas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQuery(t *testing.T) {
+	tableTests := []struct {
+		name     string
+		params   queryParams
+		expected string
+	}{
+		{
+			name:     "nil params",
+			params:   (*DedicatedServerQueryParams)(nil),
+			expected: DedicatedServerURI,
+		},
+		{
+			name:     "empty params",
+			params:   &DedicatedServerQueryParams{},
+			expected: DedicatedServerURI,
+		},
+		{
+			name:     "typed status",
+			params:   &DedicatedServerQueryParams{ProjectID: "project-1", Status: StatusActive},
+			expected: DedicatedServerURI + "?project_id=project-1&status=ACTIVE",
+		},
+		{
+			name:     "escaped values",
+			params:   &DedicatedServerQueryParams{Name: "web 1&2"},
+			expected: DedicatedServerURI + "?name=web+1%262",
+		},
+		{
+			name:     "catalog params",
+			params:   catalogQueryParams{LocationUUID: "loc-1", ServiceUUID: "svc-1"},
+			expected: DedicatedServerURI + "?location_uuid=loc-1&service_uuid=svc-1",
+		},
+		{
+			name:     "traffic params",
+			params:   &TrafficQueryParams{From: "2024-01-01T00:00:00+03:00", IP: "203.0.113.10"},
+			expected: DedicatedServerURI + "?from=2024-01-01T00%3A00%3A00%2B03%3A00&ip=203.0.113.10",
+		},
+		{
+			name:     "page params",
+			params:   pageQueryParams{params: &IPSubnetQueryParams{ServerUUID: "srv-1"}, limit: 50, offset: 100},
+			expected: DedicatedServerURI + "?limit=50&offset=100&server_uuid=srv-1",
+		},
+	}
+
+	for _, tt := range tableTests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, withQuery(DedicatedServerURI, tt.params))
+		})
+	}
+}
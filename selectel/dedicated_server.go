@@ -1,35 +1,689 @@
 package selectel
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
 )
 
 func getDedicatedServerClient(d *schema.ResourceData, meta interface{}) (*ddaas.API, diag.Diagnostics) {
+	client, err := newDedicatedServerClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
+}
+
+// newDedicatedServerClient создает клиент ddaas для проекта и региона,
+// используется также там, где нет schema.ResourceData (например, в CustomizeDiff).
+func newDedicatedServerClient(meta interface{}, projectID, region string) (*ddaas.API, error) {
 	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
 
 	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for ddaas: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for ddaas: %w", err)
 	}
 
-	region := d.Get("region").(string)
 	err = validateRegion(selvpcClient, DedicatedServer, region)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+		return nil, fmt.Errorf("can't validate region: %w", err)
 	}
 
 	endpoint, err := selvpcClient.Catalog.GetEndpoint(DedicatedServer, region)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init ddaas client: %w", err))
+		return nil, fmt.Errorf("can't get endpoint to init ddaas client: %w", err)
 	}
 
 	client, err := ddaas.New(selvpcClient.GetXAuthToken(), endpoint.URL)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't create ddaas client: %w", err))
+		return nil, fmt.Errorf("can't create ddaas client: %w", err)
 	}
 	return client, nil
 }
+
+func flattenDedicatedServerV1IPAddresses(ipAddresses []ddaas.IPAddress) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(ipAddresses))
+	for i, ip := range ipAddresses {
+		result[i] = map[string]interface{}{
+			"type":          ip.Type,
+			"ip":            ip.IP,
+			"ip_version":    ip.Version(),
+			"prefix_length": ip.Prefix(),
+			"cidr":          ip.CIDR(),
+			"netmask":       ip.Netmask,
+			"gateway":       ip.Gateway,
+		}
+	}
+
+	return result
+}
+
+// dedicatedServerV1PrimaryIP возвращает первый публичный адрес указанной версии.
+func dedicatedServerV1PrimaryIP(ipAddresses []ddaas.IPAddress, version int) string {
+	for _, ip := range ipAddresses {
+		if ip.Type == "public" && ip.Version() == version {
+			return ip.IP
+		}
+	}
+
+	return ""
+}
+
+// mergeDedicatedServerV1IPAddresses дополняет адреса сервера адресами
+// привязанных к нему подсетей без повторов.
+func mergeDedicatedServerV1IPAddresses(ipAddresses []ddaas.IPAddress, subnets []ddaas.IPSubnet) []ddaas.IPAddress {
+	result := make([]ddaas.IPAddress, 0, len(ipAddresses))
+	seen := make(map[string]struct{}, len(ipAddresses))
+
+	add := func(ip ddaas.IPAddress) {
+		if _, ok := seen[ip.IP]; ok {
+			return
+		}
+		seen[ip.IP] = struct{}{}
+		result = append(result, ip)
+	}
+
+	for _, ip := range ipAddresses {
+		add(ip)
+	}
+	for _, subnet := range subnets {
+		for _, ip := range subnet.IPAddresses() {
+			add(ip)
+		}
+	}
+
+	return result
+}
+
+func flattenDedicatedServerV1Configuration(config ddaas.Configuration) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"name":        config.Name,
+			"tariff_line": config.TariffLine,
+			"model":       config.Model,
+			"cpu":         config.CPU,
+			"ram":         config.RAM,
+			"storage":     config.Storage,
+		},
+	}
+}
+
+func flattenDedicatedServerV1Tariff(tariff ddaas.Tariff) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"name":     tariff.Name,
+			"period":   tariff.Period,
+			"price":    tariff.Price,
+			"currency": tariff.Currency,
+		},
+	}
+}
+
+func flattenDedicatedServerV1(
+	server ddaas.DedicatedServer, subnets []ddaas.IPSubnet, configurations map[string]ddaas.Configuration, tariffs map[string]ddaas.Tariff,
+) map[string]interface{} {
+	ipAddresses := mergeDedicatedServerV1IPAddresses(server.IPAddresses, subnets)
+	result := map[string]interface{}{
+		"uuid":               server.UUID,
+		"name":               server.Name,
+		"status":             string(server.Status),
+		"location_uuid":      server.LocationUUID,
+		"service_uuid":       server.ServiceUUID,
+		"configuration_uuid": server.ConfigurationUUID,
+		"tariff_uuid":        server.TariffUUID,
+		"os_image_uuid":      server.OSImageUUID,
+		"ip_addresses":       flattenDedicatedServerV1IPAddresses(ipAddresses),
+		"primary_ipv4":       dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion4),
+		"primary_ipv6":       dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion6),
+		"configuration":      []map[string]interface{}{},
+		"tariff":             []map[string]interface{}{},
+		"created_at":         server.CreatedAt.Format(time.RFC3339),
+		"updated_at":         server.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if config, ok := configurations[server.ConfigurationUUID]; ok {
+		result["configuration"] = flattenDedicatedServerV1Configuration(config)
+	}
+	if tariff, ok := tariffs[server.TariffUUID]; ok {
+		result["tariff"] = flattenDedicatedServerV1Tariff(tariff)
+	}
+
+	return result
+}
+
+type dedicatedServerPriceEstimate struct {
+	Currency     string
+	MonthlyTotal float64
+	PeriodTotal  float64
+}
+
+// estimateDedicatedServerPrice считает стоимость заказа count серверов с тарифом
+// и дополнительными опциями за месяц и за период оплаты тарифа.
+func estimateDedicatedServerPrice(tariff ddaas.Tariff, options []ddaas.PriceOption, publicIPs int, privateNetwork bool, count int) (dedicatedServerPriceEstimate, error) {
+	monthly, err := tariff.MonthlyPrice()
+	if err != nil {
+		return dedicatedServerPriceEstimate{}, fmt.Errorf("can't get price of tariff %s: %w", tariff.UUID, err)
+	}
+
+	optionCounts := map[string]int{
+		ddaas.PriceOptionPublicIP: publicIPs,
+	}
+	if privateNetwork {
+		optionCounts[ddaas.PriceOptionPrivateNetwork] = 1
+	}
+
+	for optionType, optionCount := range optionCounts {
+		if optionCount == 0 {
+			continue
+		}
+
+		option, ok := findPriceOption(options, optionType)
+		if !ok {
+			return dedicatedServerPriceEstimate{}, fmt.Errorf("price of %s option not found", optionType)
+		}
+		if option.Currency != tariff.Currency {
+			return dedicatedServerPriceEstimate{}, fmt.Errorf(
+				"currency %s of %s option differs from tariff currency %s", option.Currency, optionType, tariff.Currency,
+			)
+		}
+
+		optionMonthly, err := option.MonthlyPrice()
+		if err != nil {
+			return dedicatedServerPriceEstimate{}, fmt.Errorf("can't get price of %s option: %w", optionType, err)
+		}
+		monthly += optionMonthly * float64(optionCount)
+	}
+
+	months, err := ddaas.PeriodMonths(tariff.Period)
+	if err != nil {
+		return dedicatedServerPriceEstimate{}, err
+	}
+
+	monthly *= float64(count)
+
+	return dedicatedServerPriceEstimate{
+		Currency:     tariff.Currency,
+		MonthlyTotal: roundPrice(monthly),
+		PeriodTotal:  roundPrice(monthly * months),
+	}, nil
+}
+
+// cheapestTariff возвращает тариф с минимальной стоимостью в пересчете на месяц
+// среди тарифов в валюте currency. Если валюта не указана, все тарифы с ценой
+// должны быть в одной валюте, так как цены в разных валютах несравнимы.
+func cheapestTariff(tariffs []ddaas.Tariff, currency string) (ddaas.Tariff, error) {
+	var (
+		cheapest      ddaas.Tariff
+		cheapestPrice float64
+		found         bool
+		currencies    []string
+	)
+	for _, tariff := range tariffs {
+		if currency != "" && tariff.Currency != currency {
+			continue
+		}
+
+		price, err := tariff.MonthlyPrice()
+		if err != nil {
+			log.Printf("[WARN] Skipping tariff %s while looking for the cheapest one: %v", tariff.UUID, err)
+			continue
+		}
+		if !slices.Contains(currencies, tariff.Currency) {
+			currencies = append(currencies, tariff.Currency)
+		}
+		if !found || price < cheapestPrice {
+			cheapest, cheapestPrice, found = tariff, price, true
+		}
+	}
+
+	if !found {
+		return ddaas.Tariff{}, errors.New("no priced tariffs found")
+	}
+	if len(currencies) > 1 {
+		slices.Sort(currencies)
+		return ddaas.Tariff{}, fmt.Errorf("tariffs are priced in different currencies: %s", strings.Join(currencies, ", "))
+	}
+
+	return cheapest, nil
+}
+
+func findPriceOption(options []ddaas.PriceOption, optionType string) (ddaas.PriceOption, bool) {
+	for _, option := range options {
+		if option.Type == optionType {
+			return option, true
+		}
+	}
+
+	return ddaas.PriceOption{}, false
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
+
+// dedicatedServerCatalogSearchFilter описывает блок filter единичных data source
+// каталога. Каждый data source использует только подходящие ему поля.
+type dedicatedServerCatalogSearchFilter struct {
+	nameRegex       *regexp.Regexp
+	family          string
+	version         string
+	architecture    string
+	minCPUCores     int
+	minRAMGB        int
+	maxMonthlyPrice float64
+	period          string
+}
+
+func (f dedicatedServerCatalogSearchFilter) matchName(name string) bool {
+	return f.nameRegex == nil || f.nameRegex.MatchString(name)
+}
+
+// dedicatedServerCatalogFilterSchema возвращает схему блока filter с полем
+// name_regex и дополнительными полями конкретного data source.
+func dedicatedServerCatalogFilterSchema(extra map[string]*schema.Schema) *schema.Schema {
+	filterSchema := map[string]*schema.Schema{
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Regular expression the name must match",
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+	}
+	for key, value := range extra {
+		filterSchema[key] = value
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Search filter, the data source fails if more than one object matches it",
+		Elem: &schema.Resource{
+			Schema: filterSchema,
+		},
+	}
+}
+
+func expandDedicatedServerCatalogSearchFilter(filterSet *schema.Set) (dedicatedServerCatalogSearchFilter, error) {
+	filter := dedicatedServerCatalogSearchFilter{}
+	if filterSet.Len() == 0 {
+		return filter, nil
+	}
+
+	resourceFilterMap := filterSet.List()[0].(map[string]interface{})
+
+	if nameRegex, ok := resourceFilterMap["name_regex"]; ok && nameRegex.(string) != "" {
+		r, err := regexp.Compile(nameRegex.(string))
+		if err != nil {
+			return filter, fmt.Errorf("invalid name_regex: %w", err)
+		}
+		filter.nameRegex = r
+	}
+
+	if family, ok := resourceFilterMap["family"]; ok {
+		filter.family = family.(string)
+	}
+
+	if version, ok := resourceFilterMap["version"]; ok {
+		filter.version = version.(string)
+	}
+
+	if architecture, ok := resourceFilterMap["architecture"]; ok {
+		filter.architecture = architecture.(string)
+	}
+
+	if minCPUCores, ok := resourceFilterMap["min_cpu_cores"]; ok {
+		filter.minCPUCores = minCPUCores.(int)
+	}
+
+	if minRAMGB, ok := resourceFilterMap["min_ram_gb"]; ok {
+		filter.minRAMGB = minRAMGB.(int)
+	}
+
+	if maxMonthlyPrice, ok := resourceFilterMap["max_monthly_price"]; ok {
+		filter.maxMonthlyPrice = maxMonthlyPrice.(float64)
+	}
+
+	if period, ok := resourceFilterMap["period"]; ok {
+		filter.period = period.(string)
+	}
+
+	return filter, nil
+}
+
+// checkDedicatedServerCatalogMatches проверяет количество найденных объектов.
+// При unique несколько совпадений считаются ошибкой, иначе берется первое.
+func checkDedicatedServerCatalogMatches(object string, count int, unique bool) error {
+	if count == 0 {
+		return fmt.Errorf("%s not found", object)
+	}
+	if count > 1 && unique {
+		return fmt.Errorf("%d objects of type %s match the search criteria, refine the filter", count, object)
+	}
+
+	return nil
+}
+
+func filterDedicatedServerLocations(locations []ddaas.Location, filter dedicatedServerCatalogSearchFilter) []ddaas.Location {
+	var filtered []ddaas.Location
+	for _, location := range locations {
+		if filter.matchName(location.Name) {
+			filtered = append(filtered, location)
+		}
+	}
+
+	return filtered
+}
+
+func filterDedicatedServerConfigurations(configurations []ddaas.Configuration, filter dedicatedServerCatalogSearchFilter) []ddaas.Configuration {
+	var filtered []ddaas.Configuration
+	for _, config := range configurations {
+		if !filter.matchName(config.Name) {
+			continue
+		}
+
+		// Конфигурации, характеристики которых не удалось разобрать,
+		// не подходят под фильтры по характеристикам
+		if filter.minCPUCores > 0 {
+			cpu, err := ddaas.ParseCPU(config.CPU)
+			if err != nil || cpu.Cores < filter.minCPUCores {
+				continue
+			}
+		}
+		if filter.minRAMGB > 0 {
+			ramGB, err := ddaas.ParseRAM(config.RAM)
+			if err != nil || ramGB < filter.minRAMGB {
+				continue
+			}
+		}
+
+		filtered = append(filtered, config)
+	}
+
+	return filtered
+}
+
+func filterDedicatedServerTariffs(tariffs []ddaas.Tariff, filter dedicatedServerCatalogSearchFilter) []ddaas.Tariff {
+	var filtered []ddaas.Tariff
+	for _, tariff := range tariffs {
+		if !filter.matchName(tariff.Name) {
+			continue
+		}
+		if filter.period != "" && tariff.Period != filter.period {
+			continue
+		}
+		if filter.maxMonthlyPrice > 0 {
+			monthlyPrice, err := tariff.MonthlyPrice()
+			if err != nil || monthlyPrice > filter.maxMonthlyPrice {
+				continue
+			}
+		}
+
+		filtered = append(filtered, tariff)
+	}
+
+	return filtered
+}
+
+func filterDedicatedServerOSImages(images []ddaas.OSImage, filter dedicatedServerCatalogSearchFilter) []ddaas.OSImage {
+	var filtered []ddaas.OSImage
+	for _, image := range images {
+		if !filter.matchName(image.Name) {
+			continue
+		}
+		if filter.family != "" && image.Family != filter.family {
+			continue
+		}
+		if filter.version != "" && image.Version != filter.version {
+			continue
+		}
+		if filter.architecture != "" && image.Architecture != filter.architecture {
+			continue
+		}
+
+		filtered = append(filtered, image)
+	}
+
+	return filtered
+}
+
+func filterDedicatedServerNetworks(networks []ddaas.Network, filter dedicatedServerCatalogSearchFilter) []ddaas.Network {
+	var filtered []ddaas.Network
+	for _, network := range networks {
+		if filter.matchName(network.Name) {
+			filtered = append(filtered, network)
+		}
+	}
+
+	return filtered
+}
+
+// sortDedicatedServerOSImages сортирует образы от самого нового к самому старому
+// по версии или по имени.
+func sortDedicatedServerOSImages(images []ddaas.OSImage, sortBy string) {
+	sort.SliceStable(images, func(i, j int) bool {
+		if sortBy == "name" {
+			return images[i].Name > images[j].Name
+		}
+		return compareOSImageVersions(images[i].Version, images[j].Version) > 0
+	})
+}
+
+// compareOSImageVersions сравнивает версии вида "22.04" или "2022" по числовым
+// компонентам, нечисловые части игнорируются.
+func compareOSImageVersions(a, b string) int {
+	aParts := osImageVersionParts(a)
+	bParts := osImageVersionParts(b)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if aPart != bPart {
+			if aPart > bPart {
+				return 1
+			}
+			return -1
+		}
+	}
+
+	return 0
+}
+
+func osImageVersionParts(version string) []int {
+	fields := strings.FieldsFunc(version, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		part, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// validateDedicatedServerV1OSParams проверяет os_params по схеме параметров образа ОС
+// и возвращает все найденные ошибки одной ошибкой. Образы без описания
+// параметров не проверяются. Параметры, которых нет в образе или описание
+// которых провайдер не понимает, только логируются: API может принимать их
+// и без описания.
+func validateDedicatedServerV1OSParams(osParams map[string]interface{}, image ddaas.OSImage) error {
+	if len(image.Parameters) == 0 {
+		return nil
+	}
+
+	parameterSchema, unrecognized := image.ParameterSchema()
+	for _, name := range unrecognized {
+		log.Printf("[WARN] OS image %s (%s) has parameter %s with unrecognized description %q, it isn't validated",
+			image.Name, image.UUID, name, image.Parameters[name])
+	}
+
+	keys := make([]string, 0, len(osParams))
+	for key := range osParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		value, _ := osParams[key].(string)
+		parameter, recognized := parameterSchema[key]
+
+		switch {
+		case value == "" && recognized && parameter.Required:
+			problems = append(problems, fmt.Sprintf("%s is required", key))
+		case value == "":
+			continue
+		case !recognized:
+			if _, listed := image.Parameters[key]; !listed {
+				log.Printf("[WARN] OS image %s (%s) doesn't list os_params.%s, passing it as is", image.Name, image.UUID, key)
+			}
+		case parameter.Type == ddaas.OSParameterTypeBool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be a boolean, got %q", key, value))
+			}
+		case parameter.Type == ddaas.OSParameterTypeInt:
+			if _, err := strconv.Atoi(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be an integer, got %q", key, value))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid os_params for OS image %s (%s): %s", image.Name, image.UUID, strings.Join(problems, "; "))
+}
+
+// dedicatedServerV1OSParams возвращает значения всех полей блока os_params,
+// включая незаданные, чтобы можно было проверить обязательные параметры.
+func dedicatedServerV1OSParams(osParamsList []interface{}) map[string]interface{} {
+	osParams := make(map[string]interface{})
+	for key := range resourceDedicatedServerV1Schema()["os_params"].Elem.(*schema.Resource).Schema {
+		osParams[key] = ""
+	}
+
+	if len(osParamsList) > 0 && osParamsList[0] != nil {
+		for key, value := range osParamsList[0].(map[string]interface{}) {
+			osParams[key] = value
+		}
+	}
+
+	return osParams
+}
+
+// dedicatedServerV1RequiredPartitions разделы, которые переустановка ОС всегда стирает.
+var dedicatedServerV1RequiredPartitions = []string{"/", "/boot", "/boot/efi"}
+
+func validateDedicatedServerV1PreservePartition(v interface{}, k string) ([]string, []error) {
+	mount := v.(string)
+	if !strings.HasPrefix(mount, "/") || path.Clean(mount) != mount {
+		return nil, []error{fmt.Errorf("%s must be an absolute mount point like /data, got %q", k, mount)}
+	}
+	if slices.Contains(dedicatedServerV1RequiredPartitions, mount) {
+		return nil, []error{fmt.Errorf("%s can't contain %s, the partition is always erased by the OS reinstall", k, mount)}
+	}
+
+	return nil, nil
+}
+
+// dedicatedServerV1PartitionMounts возвращает точки монтирования из разметки
+// вида "/=50%,/var=25%,/home=25%".
+func dedicatedServerV1PartitionMounts(layout string) []string {
+	var mounts []string
+	for _, item := range strings.Split(layout, ",") {
+		mount, _, _ := strings.Cut(item, "=")
+		if mount = strings.TrimSpace(mount); mount != "" {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	return mounts
+}
+
+// dedicatedServerV1ErasedPartitions проверяет сохраняемые разделы по текущей
+// разметке сервера и новым параметрам ОС и возвращает разделы, которые
+// переустановка сотрет.
+func dedicatedServerV1ErasedPartitions(current []ddaas.Partition, preserve []string, osParams map[string]interface{}, raidChanged bool) ([]ddaas.Partition, error) {
+	if len(preserve) == 0 {
+		return current, nil
+	}
+
+	if raidChanged {
+		return nil, errors.New("preserve_partitions can't be used when os_params.soft_raid changes, the new RAID layout erases all disks")
+	}
+
+	newLayout, _ := osParams["partitions"].(string)
+	newMounts := dedicatedServerV1PartitionMounts(newLayout)
+
+	currentMounts := make([]string, len(current))
+	for i, partition := range current {
+		currentMounts[i] = partition.Mount
+	}
+
+	var problems []string
+	for _, mount := range preserve {
+		switch {
+		case !slices.Contains(currentMounts, mount):
+			problems = append(problems, fmt.Sprintf("partition %s doesn't exist on the server", mount))
+		case slices.Contains(newMounts, mount):
+			problems = append(problems, fmt.Sprintf("partition %s is also defined in os_params.partitions", mount))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid preserve_partitions (current partitions: %s): %s",
+			strings.Join(currentMounts, ", "), strings.Join(problems, "; "))
+	}
+
+	erased := make([]ddaas.Partition, 0, len(current))
+	for _, partition := range current {
+		if !slices.Contains(preserve, partition.Mount) {
+			erased = append(erased, partition)
+		}
+	}
+
+	return erased, nil
+}
+
+func dedicatedServerV1PartitionsMounts(partitions []ddaas.Partition) []string {
+	mounts := make([]string, len(partitions))
+	for i, partition := range partitions {
+		mounts[i] = partition.Mount
+	}
+
+	return mounts
+}
+
+// dedicatedServerV1ErasedPartitionsDetail описывает стираемые разделы для предупреждения.
+func dedicatedServerV1ErasedPartitionsDetail(partitions []ddaas.Partition) string {
+	items := make([]string, len(partitions))
+	for i, partition := range partitions {
+		items[i] = fmt.Sprintf("%s (%s, %s, %d GB)", partition.Mount, partition.Device, partition.FSType, partition.SizeGB)
+	}
+
+	return strings.Join(items, ", ")
+}
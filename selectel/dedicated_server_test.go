@@ -0,0 +1,322 @@
+package selectel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+func TestEstimateDedicatedServerPrice(t *testing.T) {
+	tariff := ddaas.Tariff{UUID: "t-1", Price: "27000", Period: "3 months", Currency: "RUB"}
+	options := []ddaas.PriceOption{
+		{Type: ddaas.PriceOptionPublicIP, Price: "150", Period: "month", Currency: "RUB"},
+		{Type: ddaas.PriceOptionPrivateNetwork, Price: "1200", Period: "year", Currency: "RUB"},
+	}
+
+	expected := dedicatedServerPriceEstimate{
+		Currency:     "RUB",
+		MonthlyTotal: 3 * (9000 + 2*150 + 100),
+		PeriodTotal:  3 * 3 * (9000 + 2*150 + 100),
+	}
+
+	actual, err := estimateDedicatedServerPrice(tariff, options, 2, true, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestEstimateDedicatedServerPriceErrors(t *testing.T) {
+	tariff := ddaas.Tariff{UUID: "t-1", Price: "9000", Period: "month", Currency: "RUB"}
+
+	_, err := estimateDedicatedServerPrice(tariff, nil, 1, false, 1)
+	assert.EqualError(t, err, "price of public_ip option not found")
+
+	options := []ddaas.PriceOption{
+		{Type: ddaas.PriceOptionPrivateNetwork, Price: "10", Period: "month", Currency: "USD"},
+	}
+	_, err = estimateDedicatedServerPrice(tariff, options, 0, true, 1)
+	assert.EqualError(t, err, "currency USD of private_network option differs from tariff currency RUB")
+}
+
+func TestCheapestTariff(t *testing.T) {
+	tariffs := []ddaas.Tariff{
+		{UUID: "monthly", Price: "9000", Period: "month", Currency: "RUB"},
+		{UUID: "broken", Price: "n/a", Period: "month", Currency: "RUB"},
+		{UUID: "yearly", Price: "96000", Period: "year", Currency: "RUB"},
+		{UUID: "quarterly", Price: "25500", Period: "3 months", Currency: "RUB"},
+	}
+
+	actual, err := cheapestTariff(tariffs, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "yearly", actual.UUID)
+
+	_, err = cheapestTariff([]ddaas.Tariff{{UUID: "broken", Price: "n/a", Period: "month", Currency: "RUB"}}, "")
+	assert.EqualError(t, err, "no priced tariffs found")
+}
+
+func TestCheapestTariffCurrencies(t *testing.T) {
+	tariffs := []ddaas.Tariff{
+		{UUID: "rub", Price: "9000", Period: "month", Currency: "RUB"},
+		{UUID: "usd", Price: "100", Period: "month", Currency: "USD"},
+		{UUID: "usd-yearly", Price: "1100", Period: "year", Currency: "USD"},
+	}
+
+	_, err := cheapestTariff(tariffs, "")
+	assert.EqualError(t, err, "tariffs are priced in different currencies: RUB, USD")
+
+	actual, err := cheapestTariff(tariffs, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "usd-yearly", actual.UUID)
+
+	actual, err = cheapestTariff(tariffs, "RUB")
+	assert.NoError(t, err)
+	assert.Equal(t, "rub", actual.UUID)
+
+	_, err = cheapestTariff(tariffs, "EUR")
+	assert.EqualError(t, err, "no priced tariffs found")
+}
+
+func TestMergeDedicatedServerV1IPAddresses(t *testing.T) {
+	serverAddresses := []ddaas.IPAddress{
+		{Type: "public", IP: "203.0.113.10", Netmask: "255.255.255.0", Gateway: "203.0.113.1"},
+	}
+	subnets := []ddaas.IPSubnet{
+		{
+			IPVersion:    4,
+			PrefixLength: 29,
+			Gateway:      "198.51.100.1",
+			Addresses:    []string{"198.51.100.2", "203.0.113.10"},
+		},
+	}
+
+	result := mergeDedicatedServerV1IPAddresses(serverAddresses, subnets)
+
+	expected := []ddaas.IPAddress{
+		{Type: "public", IP: "203.0.113.10", Netmask: "255.255.255.0", Gateway: "203.0.113.1"},
+		{Type: "public", IP: "198.51.100.2", IPVersion: 4, PrefixLength: 29, Netmask: "255.255.255.248", Gateway: "198.51.100.1"},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestFlattenDedicatedServerV1SubnetAddresses(t *testing.T) {
+	server := ddaas.DedicatedServer{
+		UUID:              "srv-1",
+		ConfigurationUUID: "cfg-1",
+		TariffUUID:        "trf-1",
+		IPAddresses: []ddaas.IPAddress{
+			{Type: "private", IP: "10.0.0.5", Netmask: "255.255.255.0"},
+		},
+	}
+	subnets := []ddaas.IPSubnet{
+		{IPVersion: 4, PrefixLength: 29, Gateway: "198.51.100.1", Addresses: []string{"198.51.100.2"}},
+	}
+	configurations := map[string]ddaas.Configuration{"cfg-1": {UUID: "cfg-1", Name: "EL10"}}
+
+	result := flattenDedicatedServerV1(server, subnets, configurations, map[string]ddaas.Tariff{})
+
+	assert.Len(t, result["ip_addresses"], 2)
+	assert.Equal(t, "198.51.100.2", result["primary_ipv4"])
+	assert.Equal(t, "EL10", result["configuration"].([]map[string]interface{})[0]["name"])
+	assert.Empty(t, result["tariff"])
+}
+
+func TestDedicatedServerV1PrimaryIP(t *testing.T) {
+	ipAddresses := []ddaas.IPAddress{
+		{Type: "private", IP: "10.0.0.5", Netmask: "255.255.255.0"},
+		{Type: "public", IP: "2001:db8::10", PrefixLength: 64, Gateway: "2001:db8::1"},
+		{Type: "public", IP: "203.0.113.10", Netmask: "255.255.255.0", Gateway: "203.0.113.1"},
+		{Type: "public", IP: "198.51.100.2", IPVersion: 4, PrefixLength: 29},
+	}
+
+	assert.Equal(t, "203.0.113.10", dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion4))
+	assert.Equal(t, "2001:db8::10", dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion6))
+	assert.Equal(t, "", dedicatedServerV1PrimaryIP(ipAddresses[:1], ddaas.IPVersion4))
+}
+
+func TestExpandDedicatedServerCatalogSearchFilter(t *testing.T) {
+	filterSet := schema.NewSet(schema.HashResource(dedicatedServerCatalogFilterSchema(map[string]*schema.Schema{
+		"min_cpu_cores": {Type: schema.TypeInt, Optional: true},
+	}).Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{
+			"name_regex":    "^EL",
+			"min_cpu_cores": 8,
+		},
+	})
+
+	filter, err := expandDedicatedServerCatalogSearchFilter(filterSet)
+
+	assert.NoError(t, err)
+	assert.True(t, filter.matchName("EL10-SSD"))
+	assert.False(t, filter.matchName("PL11-SSD"))
+	assert.Equal(t, 8, filter.minCPUCores)
+}
+
+func TestFilterDedicatedServerConfigurations(t *testing.T) {
+	configurations := []ddaas.Configuration{
+		{UUID: "small", Name: "EL10", CPU: "Intel Xeon E-2236 3.4 GHz 6 cores", RAM: "32 GB"},
+		{UUID: "large", Name: "EL20", CPU: "2 x Intel Xeon Gold 6240 2.6 GHz 18 cores", RAM: "384 GB"},
+		{UUID: "unknown", Name: "EL30", CPU: "custom", RAM: "custom"},
+	}
+
+	result := filterDedicatedServerConfigurations(configurations, dedicatedServerCatalogSearchFilter{
+		minCPUCores: 8,
+		minRAMGB:    64,
+	})
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "large", result[0].UUID)
+}
+
+func TestFilterDedicatedServerTariffs(t *testing.T) {
+	tariffs := []ddaas.Tariff{
+		{UUID: "monthly", Period: "1 month", Price: "10000"},
+		{UUID: "yearly", Period: "12 months", Price: "96000"},
+	}
+
+	result := filterDedicatedServerTariffs(tariffs, dedicatedServerCatalogSearchFilter{
+		maxMonthlyPrice: 9000,
+	})
+	assert.Len(t, result, 1)
+	assert.Equal(t, "yearly", result[0].UUID)
+
+	result = filterDedicatedServerTariffs(tariffs, dedicatedServerCatalogSearchFilter{
+		period: "1 month",
+	})
+	assert.Len(t, result, 1)
+	assert.Equal(t, "monthly", result[0].UUID)
+}
+
+func TestSortDedicatedServerOSImages(t *testing.T) {
+	images := []ddaas.OSImage{
+		{UUID: "focal", Name: "Ubuntu 20.04 LTS", Version: "20.04"},
+		{UUID: "noble", Name: "Ubuntu 24.04 LTS", Version: "24.04"},
+		{UUID: "jammy", Name: "Ubuntu 22.04 LTS", Version: "22.04"},
+	}
+
+	sortDedicatedServerOSImages(images, "version")
+
+	assert.Equal(t, "noble", images[0].UUID)
+	assert.Equal(t, "jammy", images[1].UUID)
+	assert.Equal(t, "focal", images[2].UUID)
+}
+
+func TestCompareOSImageVersions(t *testing.T) {
+	assert.Equal(t, 1, compareOSImageVersions("22.04", "20.04"))
+	assert.Equal(t, -1, compareOSImageVersions("9", "10"))
+	assert.Equal(t, 0, compareOSImageVersions("8.0", "8"))
+}
+
+func TestCheckDedicatedServerCatalogMatches(t *testing.T) {
+	assert.NoError(t, checkDedicatedServerCatalogMatches("tariff", 1, true))
+	assert.NoError(t, checkDedicatedServerCatalogMatches("tariff", 2, false))
+	assert.EqualError(t, checkDedicatedServerCatalogMatches("tariff", 0, false), "tariff not found")
+	assert.EqualError(t, checkDedicatedServerCatalogMatches("tariff", 2, true), "2 objects of type tariff match the search criteria, refine the filter")
+}
+
+func TestValidateDedicatedServerV1OSParams(t *testing.T) {
+	windows := ddaas.OSImage{
+		UUID: "win-1",
+		Name: "Windows Server 2022",
+		Parameters: map[string]string{
+			"login":     "string",
+			"password":  "string,required",
+			"soft_raid": "bool",
+		},
+	}
+
+	osParams := dedicatedServerV1OSParams([]interface{}{
+		map[string]interface{}{
+			"login":     "Administrator",
+			"soft_raid": "raid1",
+			"user_data": "#cloud-config",
+		},
+	})
+
+	err := validateDedicatedServerV1OSParams(osParams, windows)
+
+	assert.EqualError(t, err, "invalid os_params for OS image Windows Server 2022 (win-1): "+
+		"password is required; soft_raid must be a boolean, got \"raid1\"")
+}
+
+func TestValidateDedicatedServerV1OSParamsValid(t *testing.T) {
+	linux := ddaas.OSImage{
+		Parameters: map[string]string{
+			"password":  "string",
+			"user_data": "string",
+		},
+	}
+
+	assert.NoError(t, validateDedicatedServerV1OSParams(dedicatedServerV1OSParams(nil), linux))
+	assert.NoError(t, validateDedicatedServerV1OSParams(dedicatedServerV1OSParams([]interface{}{
+		map[string]interface{}{"user_data": "#cloud-config"},
+	}), linux))
+
+	// Параметры, которых нет в образе или с непонятным описанием, не проверяются
+	assert.NoError(t, validateDedicatedServerV1OSParams(dedicatedServerV1OSParams([]interface{}{
+		map[string]interface{}{"ssh_key": "ssh-ed25519 AAAA", "partitions": "/=100%"},
+	}), ddaas.OSImage{
+		Parameters: map[string]string{"partitions": "Disk layout, required"},
+	}))
+
+	// Образы без описания параметров не проверяются
+	assert.NoError(t, validateDedicatedServerV1OSParams(dedicatedServerV1OSParams([]interface{}{
+		map[string]interface{}{"soft_raid": "raid1"},
+	}), ddaas.OSImage{}))
+}
+
+func TestDedicatedServerTrafficPeriod(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	from, to, err := dedicatedServerTrafficPeriod("", "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, now, to)
+
+	from, to, err = dedicatedServerTrafficPeriod("2024-02-01T00:00:00Z", "2024-03-01T00:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = dedicatedServerTrafficPeriod("2024-03-10T00:00:00Z", "2024-03-01T00:00:00Z", now)
+	assert.Error(t, err)
+}
+
+func TestValidateDedicatedServerV1PreservePartition(t *testing.T) {
+	for _, mount := range []string{"/data", "/var/lib/postgresql"} {
+		_, errs := validateDedicatedServerV1PreservePartition(mount, "preserve_partitions")
+		assert.Empty(t, errs, mount)
+	}
+	for _, mount := range []string{"data", "/data/", "/", "/boot", "/boot/efi"} {
+		_, errs := validateDedicatedServerV1PreservePartition(mount, "preserve_partitions")
+		assert.Len(t, errs, 1, mount)
+	}
+}
+
+func TestDedicatedServerV1ErasedPartitions(t *testing.T) {
+	current := []ddaas.Partition{
+		{Mount: "/boot", Device: "/dev/md0", FSType: "ext4", SizeGB: 1},
+		{Mount: "/", Device: "/dev/md1", FSType: "ext4", SizeGB: 100},
+		{Mount: "/data", Device: "/dev/md2", FSType: "xfs", SizeGB: 3600},
+	}
+	osParams := map[string]interface{}{"partitions": "/=50%,/var=50%"}
+
+	erased, err := dedicatedServerV1ErasedPartitions(current, []string{"/data"}, osParams, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/boot", "/"}, dedicatedServerV1PartitionsMounts(erased))
+	assert.Equal(t, "/boot (/dev/md0, ext4, 1 GB), / (/dev/md1, ext4, 100 GB)", dedicatedServerV1ErasedPartitionsDetail(erased))
+
+	erased, err = dedicatedServerV1ErasedPartitions(current, nil, osParams, true)
+	assert.NoError(t, err)
+	assert.Equal(t, current, erased)
+
+	_, err = dedicatedServerV1ErasedPartitions(current, []string{"/data"}, osParams, true)
+	assert.ErrorContains(t, err, "soft_raid")
+
+	_, err = dedicatedServerV1ErasedPartitions(current, []string{"/home", "/data"}, map[string]interface{}{"partitions": "/=50%,/data=50%"}, false)
+	assert.EqualError(t, err, "invalid preserve_partitions (current partitions: /boot, /, /data): "+
+		"partition /home doesn't exist on the server; partition /data is also defined in os_params.partitions")
+}
@@ -0,0 +1,120 @@
+package selectel
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Prevents the resource from being deleted or replaced while set to true",
+	}
+}
+
+// forceNewFunc marks key as requiring a replacement of the resource.
+type forceNewFunc func(key string) error
+
+// forceNewDiffFunc is a CustomizeDiff func that forces replacements only
+// through forceNew, so that deletionProtectionDiff sees them.
+// schema.ResourceDiff doesn't expose the keys forced by d.ForceNew.
+type forceNewDiffFunc func(ctx context.Context, d *schema.ResourceDiff, meta interface{}, forceNew forceNewFunc) error
+
+// forceNewDiff adapts f for resources without deletion protection.
+func forceNewDiff(f forceNewDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		return f(ctx, d, meta, d.ForceNew)
+	}
+}
+
+// withoutReplacement adapts a CustomizeDiff func that never forces
+// a replacement for deletionProtectionDiff.
+func withoutReplacement(f schema.CustomizeDiffFunc) forceNewDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}, _ forceNewFunc) error {
+		return f(ctx, d, meta)
+	}
+}
+
+// deletionProtectionDiff runs the other CustomizeDiff funcs of the resource
+// and then fails the plan when a protected resource would be replaced, either
+// because a ForceNew attribute, including a nested one, has changed or
+// because funcs forced a replacement with forceNew.
+func deletionProtectionDiff(object string, resourceSchema map[string]*schema.Schema, funcs ...forceNewDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		forced := make(map[string]struct{})
+		forceNew := func(key string) error {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+			forced[key] = struct{}{}
+
+			return nil
+		}
+
+		for _, f := range funcs {
+			if err := f(ctx, d, meta, forceNew); err != nil {
+				return err
+			}
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+
+		// Delete is called with the prior state, so the old value decides.
+		protected, _ := d.GetChange("deletion_protection")
+		if !protected.(bool) {
+			return nil
+		}
+
+		for _, key := range d.GetChangedKeysPrefix("") {
+			if schemaKeyForcesNew(resourceSchema, key) {
+				forced[strings.SplitN(key, ".", 2)[0]] = struct{}{}
+			}
+		}
+
+		keys := make([]string, 0, len(forced))
+		for key := range forced {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if d.HasChange(key) {
+				return errDeletionProtectedReplace(object, d.Id(), key)
+			}
+		}
+
+		return nil
+	}
+}
+
+// schemaKeyForcesNew reports whether the attribute at the flatmap key, for
+// example os_params.0.soft_raid, or one of the blocks containing it is ForceNew.
+func schemaKeyForcesNew(resourceSchema map[string]*schema.Schema, key string) bool {
+	parts := strings.Split(key, ".")
+	current := resourceSchema
+	// Every second part is a list index or a set hash of the block.
+	for i := 0; i < len(parts); i += 2 {
+		s, ok := current[parts[i]]
+		if !ok {
+			return false
+		}
+		if s.ForceNew {
+			return true
+		}
+
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok {
+			return false
+		}
+		current = elem.Schema
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package selectel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeletionProtectionResource() *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"region": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"flavor": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"settings": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"engine": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+		"deletion_protection": deletionProtectionSchema(),
+	}
+
+	return &schema.Resource{
+		Schema: resourceSchema,
+		CustomizeDiff: deletionProtectionDiff(objectDatastore, resourceSchema,
+			func(_ context.Context, d *schema.ResourceDiff, _ interface{}, forceNew forceNewFunc) error {
+				if d.HasChange("flavor") {
+					return forceNew("flavor")
+				}
+				return nil
+			},
+			withoutReplacement(func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+				if d.HasChange("name") {
+					return d.SetNewComputed("status")
+				}
+				return nil
+			}),
+		),
+	}
+}
+
+func TestDeletionProtectionDiff(t *testing.T) {
+	tableTests := []struct {
+		name        string
+		protected   string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:      "protected in-place update",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "new-name",
+				"flavor":              "small",
+				"region":              "ru-3",
+				"deletion_protection": true,
+			},
+		},
+		{
+			name:      "protected replacement",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "old-name",
+				"flavor":              "small",
+				"region":              "ru-7",
+				"deletion_protection": true,
+			},
+			expectedErr: "changing region requires replacing datastore 'datastore-id', which has deletion protection enabled",
+		},
+		{
+			name:      "protection disabled in the same plan",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "old-name",
+				"flavor":              "small",
+				"region":              "ru-7",
+				"deletion_protection": false,
+			},
+			expectedErr: "changing region requires replacing datastore 'datastore-id', which has deletion protection enabled",
+		},
+		{
+			name:      "protected replacement forced in CustomizeDiff",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "old-name",
+				"region":              "ru-3",
+				"flavor":              "large",
+				"deletion_protection": true,
+			},
+			expectedErr: "changing flavor requires replacing datastore 'datastore-id', which has deletion protection enabled",
+		},
+		{
+			name:      "protected replacement of a nested attribute",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":   "old-name",
+				"region": "ru-3",
+				"flavor": "small",
+				"settings": []interface{}{
+					map[string]interface{}{"engine": "v2"},
+				},
+				"deletion_protection": true,
+			},
+			expectedErr: "changing settings requires replacing datastore 'datastore-id', which has deletion protection enabled",
+		},
+		{
+			name:      "unprotected replacement forced in CustomizeDiff",
+			protected: "false",
+			config: map[string]interface{}{
+				"name":   "old-name",
+				"region": "ru-3",
+				"flavor": "large",
+			},
+		},
+		{
+			name:      "unprotected replacement",
+			protected: "false",
+			config: map[string]interface{}{
+				"name":   "old-name",
+				"flavor": "small",
+				"region": "ru-7",
+			},
+		},
+	}
+
+	for _, test := range tableTests {
+		t.Run(test.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "datastore-id",
+				Attributes: map[string]string{
+					"id":                  "datastore-id",
+					"name":                "old-name",
+					"region":              "ru-3",
+					"flavor":              "small",
+					"deletion_protection": test.protected,
+				},
+			}
+			config := terraform.NewResourceConfigRaw(test.config)
+
+			_, err := testDeletionProtectionResource().SimpleDiff(context.Background(), state, config, nil)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSchemaKeyForcesNew(t *testing.T) {
+	resourceSchema := testDeletionProtectionResource().Schema
+
+	assert.True(t, schemaKeyForcesNew(resourceSchema, "region"))
+	assert.True(t, schemaKeyForcesNew(resourceSchema, "settings.0.engine"))
+	assert.False(t, schemaKeyForcesNew(resourceSchema, "settings.#"))
+	assert.False(t, schemaKeyForcesNew(resourceSchema, "name"))
+	assert.False(t, schemaKeyForcesNew(resourceSchema, "unknown.0.key"))
+}
+
+// TestNoDirectResourceDiffForceNew fails on direct d.ForceNew calls, which
+// deletionProtectionDiff doesn't see. CustomizeDiff funcs must use the
+// forceNew argument of forceNewDiffFunc instead.
+func TestNoDirectResourceDiffForceNew(t *testing.T) {
+	forceNewCall := regexp.MustCompile(`\.ForceNew\(`)
+
+	files, err := filepath.Glob("*.go")
+	assert.NoError(t, err)
+
+	for _, file := range files {
+		if file == "deletion_protection.go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+
+		for i, line := range strings.Split(string(content), "\n") {
+			if forceNewCall.MatchString(line) {
+				t.Errorf("%s:%d: use forceNewDiffFunc instead of calling ForceNew directly", file, i+1)
+			}
+		}
+	}
+}
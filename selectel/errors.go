@@ -107,3 +107,15 @@ func errParseDatastoreV1Restore(err error) error {
 func errParseDatastoreV1FloatingIPs(err error) error {
 	return fmt.Errorf("got error parsing floating IPs opts: %s", err)
 }
+
+func errDeletionProtected(object, id string) error {
+	return fmt.Errorf("%s '%s' has deletion protection enabled, set deletion_protection to false and apply before deleting it", object, id)
+}
+
+func errDeletionProtectedReplace(object, id, attr string) error {
+	return fmt.Errorf("changing %s requires replacing %s '%s', which has deletion protection enabled", attr, object, id)
+}
+
+func errDeletionProtectedScaleDown(object, id string, from, to int) error {
+	return fmt.Errorf("decreasing server_count from %d to %d deletes servers of %s '%s', which has deletion protection enabled", from, to, object, id)
+}
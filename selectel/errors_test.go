@@ -243,3 +243,11 @@ func TestErrParseDatastoreV1Restore(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestErrDeletionProtected(t *testing.T) {
+	expected := errors.New("datastore 'b311ce58' has deletion protection enabled, set deletion_protection to false and apply before deleting it")
+
+	actual := errDeletionProtected(objectDatastore, "b311ce58")
+
+	assert.Equal(t, expected, actual)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	v1 "github.com/selectel/mks-go/pkg/v1"
 	"github.com/selectel/mks-go/pkg/v1/cluster"
@@ -90,6 +93,34 @@ func waitForMKSNodegroupV1ActiveState(
 	return nil
 }
 
+func waitForMKSNodegroupV1Deletion(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{strconv.Itoa(http.StatusOK)},
+		Target:  []string{strconv.Itoa(http.StatusNotFound)},
+		Refresh: func() (result interface{}, state string, err error) {
+			result, response, err := nodegroup.Get(ctx, client, clusterID, nodegroupID)
+			if err != nil {
+				if response != nil {
+					return result, strconv.Itoa(response.StatusCode), nil
+				}
+
+				return nil, "", err
+			}
+
+			return result, strconv.Itoa(response.StatusCode), err
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
 func mksNodegroupV1StateRefreshFunc(
 	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
 ) resource.StateRefreshFunc {
@@ -238,7 +269,16 @@ func checkVersionIsSupported(kubeVersions []*kubeversion.View, desiredMinorVersi
 	return false, nil
 }
 
-func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient) error {
+// mksClusterV1UpgradeHop is a completed minor version upgrade of a cluster.
+type mksClusterV1UpgradeHop struct {
+	From string
+	To   string
+}
+
+// upgradeMKSClusterV1KubeVersion upgrades the cluster to the desired version.
+// It returns the completed hops when the cluster is upgraded through several
+// minor versions with allow_sequential_upgrades.
+func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient) ([]mksClusterV1UpgradeHop, error) {
 	oldVersion, newVersion := d.GetChange("kube_version")
 	currentVersion := oldVersion.(string)
 	desiredVersion := newVersion.(string)
@@ -248,58 +288,63 @@ func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData,
 
 	kubeVersions, _, err := kubeversion.List(ctx, client)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Compare current and desired major versions.
 	currentMajor, err := kubeVersionToMajor(currentVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a major part of the current version %s: %s", currentVersion, err)
+		return nil, fmt.Errorf("error getting a major part of the current version %s: %s", currentVersion, err)
 	}
 	desiredMajor, err := kubeVersionToMajor(desiredVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a major part of the desired version %s: %s", desiredVersion, err)
+		return nil, fmt.Errorf("error getting a major part of the desired version %s: %s", desiredVersion, err)
 	}
 	if desiredMajor != currentMajor {
-		return fmt.Errorf("current version %s can't be upgraded to version %s", currentVersion, desiredVersion)
+		return nil, fmt.Errorf("current version %s can't be upgraded to version %s", currentVersion, desiredVersion)
 	}
 
 	// Compare current and desired minor versions.
 	currentMinor, err := kubeVersionTrimToMinor(currentVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a minor part of the current version %s: %s", currentVersion, err)
+		return nil, fmt.Errorf("error getting a minor part of the current version %s: %s", currentVersion, err)
 	}
 	desiredMinor, err := kubeVersionTrimToMinor(desiredVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a minor part of the desired version %s: %s", desiredVersion, err)
+		return nil, fmt.Errorf("error getting a minor part of the desired version %s: %s", desiredVersion, err)
 	}
 	if desiredMinor != currentMinor {
 		log.Print("[DEBUG] upgrading minor version")
 
 		latestMinorVersion, err := parseMKSKubeVersionsV1Latest(kubeVersions)
 		if err != nil {
-			return fmt.Errorf("can't find latest minor version: %s", err)
+			return nil, fmt.Errorf("can't find latest minor version: %s", err)
 		}
 
 		if latestMinorVersion == currentMinor {
-			return fmt.Errorf("the cluster is already on the latest available minor version: %s", currentMinor)
+			return nil, fmt.Errorf("the cluster is already on the latest available minor version: %s", currentMinor)
 		}
 
 		// Increment minor version.
 		currentMinorNew, err := kubeVersionTrimToMinorIncremented(currentVersion)
 		if err != nil {
-			return fmt.Errorf("error getting incremented minor part of the current version %s: %s", currentVersion, err)
+			return nil, fmt.Errorf("error getting incremented minor part of the current version %s: %s", currentVersion, err)
 		}
 
 		// Check that next minor version is equal to desired version.
 		if currentMinorNew != desiredMinor {
-			return fmt.Errorf("invalid minor version: %s, kubernetes versions must be upgraded one by one", desiredMinor)
+			if !d.Get("allow_sequential_upgrades").(bool) {
+				return nil, fmt.Errorf("invalid minor version: %s, kubernetes versions must be upgraded one by one, "+
+					"set allow_sequential_upgrades to upgrade through all intermediate versions", desiredMinor)
+			}
+
+			return upgradeMKSClusterV1MinorVersionSequentially(ctx, d, client, kubeVersions, currentVersion, desiredVersion)
 		}
 
 		// Check that new minor version is supported.
 		isSupported, err := checkVersionIsSupported(kubeVersions, desiredVersion)
 		if err != nil {
-			return fmt.Errorf("can't check support for version: %s", err)
+			return nil, fmt.Errorf("can't check support for version: %s", err)
 		}
 
 		if !isSupported {
@@ -308,17 +353,17 @@ func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData,
 
 		_, _, err = cluster.UpgradeMinorVersion(ctx, client, d.Id())
 		if err != nil {
-			return fmt.Errorf("error upgrading minor version: %s", err)
+			return nil, fmt.Errorf("error upgrading minor version: %s", err)
 		}
 
 		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutUpdate)
 		err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
 		if err != nil {
-			return fmt.Errorf("error waiting for the minor version upgrade: %s", err)
+			return nil, fmt.Errorf("error waiting for the minor version upgrade: %s", err)
 		}
 
-		return nil
+		return nil, nil
 	}
 
 	log.Print("[DEBUG] upgrading patch version")
@@ -326,38 +371,167 @@ func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData,
 	// Get the latest patch versions for every minor version.
 	latestPatchVersions, err := mksClusterV1GetLatestPatchVersions(ctx, client)
 	if err != nil {
-		return fmt.Errorf("error getting latest patch versions: %s", err)
+		return nil, fmt.Errorf("error getting latest patch versions: %s", err)
 	}
 
 	// Find the latest patch version corresponding to the current minor version.
 	latestVersion, ok := latestPatchVersions[currentMinor]
 	if !ok {
-		return fmt.Errorf("unable to find the latest patch version for the current minor version %s", currentMinor)
+		return nil, fmt.Errorf("unable to find the latest patch version for the current minor version %s", currentMinor)
 	}
 
 	log.Printf("[DEBUG] latest kube version: %s", latestVersion)
 
 	if desiredVersion != latestVersion {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"current version %s can't be upgraded to version %s, the latest available patch version is: %s",
 			currentVersion, desiredVersion, latestVersion)
 	}
 
 	_, _, err = cluster.UpgradePatchVersion(ctx, client, d.Id())
 	if err != nil {
-		return fmt.Errorf("error upgrading patch version: %s", err)
+		return nil, fmt.Errorf("error upgrading patch version: %s", err)
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
 	timeout := d.Timeout(schema.TimeoutUpdate)
 	err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
 	if err != nil {
-		return fmt.Errorf("error waiting for the patch version upgrade: %s", err)
+		return nil, fmt.Errorf("error waiting for the patch version upgrade: %s", err)
+	}
+
+	return nil, nil
+}
+
+// upgradeMKSClusterV1MinorVersionSequentially upgrades the cluster through
+// every minor version between the current and the desired ones. After each
+// hop the cluster and all its nodegroups must become ACTIVE. All hops share
+// the update timeout, so every wait is limited by the time left in ctx.
+func upgradeMKSClusterV1MinorVersionSequentially(
+	ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient, kubeVersions []*kubeversion.View, currentVersion, desiredVersion string,
+) ([]mksClusterV1UpgradeHop, error) {
+	path, err := mksClusterV1MinorUpgradePath(kubeVersions, currentVersion, desiredVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var hops []mksClusterV1UpgradeHop
+	from := currentVersion
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	for _, minor := range path {
+		log.Printf("[DEBUG] upgrading cluster %s from %s to minor version %s", d.Id(), from, minor)
+		_, _, err := cluster.UpgradeMinorVersion(ctx, client, d.Id())
+		if err != nil {
+			return hops, fmt.Errorf("error upgrading minor version from %s to %s: %s", from, minor, err)
+		}
+
+		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
+		err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), remainingTimeout(ctx, timeout))
+		if err != nil {
+			return hops, fmt.Errorf("error waiting for the minor version upgrade to %s: %s", minor, err)
+		}
+
+		err = waitForMKSClusterV1NodegroupsActiveState(ctx, client, d.Id(), remainingTimeout(ctx, timeout))
+		if err != nil {
+			return hops, fmt.Errorf("nodegroups are not healthy after the minor version upgrade to %s: %s", minor, err)
+		}
+
+		mksCluster, _, err := cluster.Get(ctx, client, d.Id())
+		if err != nil {
+			return hops, errGettingObject(objectCluster, d.Id(), err)
+		}
+
+		hops = append(hops, mksClusterV1UpgradeHop{From: from, To: mksCluster.KubeVersion})
+		from = mksCluster.KubeVersion
+	}
+
+	return hops, nil
+}
+
+// remainingTimeout returns timeout limited by the time left before the ctx
+// deadline.
+func remainingTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			return remaining
+		}
+	}
+
+	return timeout
+}
+
+// mksClusterV1MinorUpgradePath returns the minor versions the cluster has to
+// be upgraded through to reach the desired version. All intermediate minor
+// versions must be available.
+func mksClusterV1MinorUpgradePath(kubeVersions []*kubeversion.View, currentVersion, desiredVersion string) ([]string, error) {
+	major, err := kubeVersionToMajor(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	currentMinor, err := kubeVersionToMinor(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	desiredMinor, err := kubeVersionToMinor(desiredVersion)
+	if err != nil {
+		return nil, err
+	}
+	if desiredMinor <= currentMinor {
+		return nil, fmt.Errorf("current version %s can't be upgraded to version %s", currentVersion, desiredVersion)
+	}
+
+	path := make([]string, 0, desiredMinor-currentMinor)
+	for minor := currentMinor + 1; minor <= desiredMinor; minor++ {
+		version := fmt.Sprintf("%d.%d", major, minor)
+		if minor < desiredMinor {
+			isSupported, err := checkVersionIsSupported(kubeVersions, version)
+			if err != nil {
+				return nil, fmt.Errorf("can't check support for version: %s", err)
+			}
+			if !isSupported {
+				return nil, fmt.Errorf("intermediate minor version %s isn't available, "+
+					"the cluster can't be upgraded from %s to %s", version, currentVersion, desiredVersion)
+			}
+		}
+		path = append(path, version)
+	}
+
+	return path, nil
+}
+
+// waitForMKSClusterV1NodegroupsActiveState waits for all nodegroups of the
+// cluster to become ACTIVE.
+func waitForMKSClusterV1NodegroupsActiveState(
+	ctx context.Context, client *v1.ServiceClient, clusterID string, timeout time.Duration,
+) error {
+	nodegroups, _, err := nodegroup.List(ctx, client, clusterID)
+	if err != nil {
+		return errGettingObject(objectNodegroups, clusterID, err)
+	}
+
+	for _, ng := range nodegroups {
+		if err := waitForMKSNodegroupV1ActiveState(ctx, client, clusterID, ng.ID, timeout); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// mksClusterV1UpgradeHopsDiags reports completed minor version upgrades.
+func mksClusterV1UpgradeHopsDiags(clusterID string, hops []mksClusterV1UpgradeHop) diag.Diagnostics {
+	diags := make(diag.Diagnostics, len(hops))
+	for i, hop := range hops {
+		diags[i] = diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Cluster %s upgraded from %s to %s", clusterID, hop.From, hop.To),
+			Detail:   "The cluster and all its nodegroups became ACTIVE after the upgrade.",
+		}
+	}
+
+	return diags
+}
+
 // kubeVersionToMajor returns given Kubernetes version major part.
 func kubeVersionToMajor(kubeVersion string) (int, error) {
 	// Trim version prefix if needed.
@@ -498,6 +672,33 @@ func flattenMKSNodegroupV1Nodes(views []*node.View) []map[string]interface{} {
 	return nodes
 }
 
+func flattenMKSNodegroupsV1(views []*nodegroup.ListView) []interface{} {
+	nodegroups := make([]interface{}, len(views))
+	for i, view := range views {
+		nodegroups[i] = map[string]interface{}{
+			"id":                           view.ID,
+			"status":                       string(view.Status),
+			"availability_zone":            view.AvailabilityZone,
+			"flavor_id":                    view.FlavorID,
+			"volume_gb":                    view.VolumeGB,
+			"volume_type":                  view.VolumeType,
+			"local_volume":                 view.LocalVolume,
+			"nodes_count":                  len(view.Nodes),
+			"enable_autoscale":             view.EnableAutoscale,
+			"autoscale_min_nodes":          view.AutoscaleMinNodes,
+			"autoscale_max_nodes":          view.AutoscaleMaxNodes,
+			"nodegroup_type":               view.NodegroupType,
+			"install_nvidia_device_plugin": view.InstallNvidiaDevicePlugin,
+			"preemptible":                  view.Preemptible,
+			"labels":                       view.Labels,
+			"taints":                       flattenMKSNodegroupV1Taints(view.Taints),
+			"nodes":                        flattenMKSNodegroupV1Nodes(view.Nodes),
+		}
+	}
+
+	return nodegroups
+}
+
 func flattenMKSNodegroupV1Taints(views []nodegroup.Taint) []interface{} {
 	taints := make([]interface{}, len(views))
 	for i, view := range views {
@@ -555,6 +756,25 @@ func flattenAdmissionControllersFromSlice(kubeVersion string, admissionControlle
 	return availableAdmissionControllers
 }
 
+// findMKSClusterV1ByName returns the only cluster with the given name.
+func findMKSClusterV1ByName(clusters []*cluster.View, name string) (*cluster.View, error) {
+	var found []*cluster.View
+	for _, view := range clusters {
+		if view.Name == name {
+			found = append(found, view)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("cluster with name %q is not found", name)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("found %d clusters with name %q, use cluster_id instead", len(found), name)
+	}
+}
+
 func flattenMKSClusterV1OIDC(view *cluster.View) []interface{} {
 	return []interface{}{map[string]interface{}{
 		"enabled":        view.KubernetesOptions.OIDC.Enabled,
@@ -785,6 +1005,117 @@ func checkQuotasForCluster(projectQuotas []*quotas.Quota, zonal bool) error {
 	return nil
 }
 
+// mksNodegroupV1ConfigUserData returns user_data from the resource configuration.
+// d.Get returns the hash kept in the state when user_data hasn't changed, for
+// example during a blue/green replacement.
+func mksNodegroupV1ConfigUserData(d *schema.ResourceData) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	userData := config.GetAttr("user_data")
+	if userData.IsNull() || !userData.IsKnown() {
+		return ""
+	}
+
+	return userData.AsString()
+}
+
+// expandMKSNodegroupV1CreateOpts builds nodegroup create options from the
+// resource configuration.
+func expandMKSNodegroupV1CreateOpts(d *schema.ResourceData) (*nodegroup.CreateOpts, error) {
+	// Plain text user data is encoded here, the state keeps only its hash.
+	userData, err := encodeUserData(mksNodegroupV1ConfigUserData(d))
+	if err != nil {
+		return nil, err
+	}
+
+	installNvidiaDevicePlugin := d.Get("install_nvidia_device_plugin").(bool)
+	preemptible := d.Get("preemptible").(bool)
+	createOpts := &nodegroup.CreateOpts{
+		Count:                     d.Get("nodes_count").(int),
+		FlavorID:                  d.Get("flavor_id").(string),
+		CPUs:                      d.Get("cpus").(int),
+		RAMMB:                     d.Get("ram_mb").(int),
+		VolumeGB:                  d.Get("volume_gb").(int),
+		VolumeType:                d.Get("volume_type").(string),
+		LocalVolume:               d.Get("local_volume").(bool),
+		KeypairName:               d.Get("keypair_name").(string),
+		AffinityPolicy:            d.Get("affinity_policy").(string),
+		AvailabilityZone:          d.Get("availability_zone").(string),
+		UserData:                  userData,
+		InstallNvidiaDevicePlugin: &installNvidiaDevicePlugin,
+		Preemptible:               &preemptible,
+	}
+
+	if createOpts.LocalVolume && createOpts.VolumeType != "" {
+		return nil, errors.New("can't use local_volume=true with volume_type")
+	}
+	if !createOpts.LocalVolume && createOpts.VolumeType == "" {
+		return nil, errors.New("can't use local_volume=false without specify volume_type")
+	}
+
+	// Check nodegroup autoscaling options.
+	if v, ok := d.GetOk("enable_autoscale"); ok {
+		enableAutoscale := v.(bool)
+		createOpts.EnableAutoscale = &enableAutoscale
+
+		// d.GetOk returns false on autoscale_min_nodes set as 0.
+		autoscaleMinNodes := d.Get("autoscale_min_nodes").(int)
+		createOpts.AutoscaleMinNodes = &autoscaleMinNodes
+
+		if v, ok := d.GetOk("autoscale_max_nodes"); ok {
+			autoscaleMaxNodes := v.(int)
+			createOpts.AutoscaleMaxNodes = &autoscaleMaxNodes
+		}
+	}
+
+	labels := d.Get("labels").(map[string]interface{})
+	createOpts.Labels = expandMKSNodegroupV1Labels(labels)
+
+	taints := d.Get("taints").([]interface{})
+	createOpts.Taints = expandMKSNodegroupV1Taints(taints)
+
+	return createOpts, nil
+}
+
+// checkMKSNodegroupV1Quotas checks that the project has enough quotas
+// for the nodes of the nodegroup.
+func checkMKSNodegroupV1Quotas(selvpcClient *selvpcclient.Client, projectID, region string, createOpts *nodegroup.CreateOpts) error {
+	// Skip quota validation cause we can not open flavor and check resource claim.
+	if createOpts.FlavorID != "" {
+		return nil
+	}
+
+	filters := []func(url.Values){
+		quotas.WithResourceFilter("compute_cores"),
+		quotas.WithResourceFilter("compute_ram"),
+	}
+	if createOpts.LocalVolume {
+		filters = append(filters, quotas.WithResourceFilter("volume_gigabytes_local"))
+	} else {
+		// Removing an availability zone from volume type.
+		// For example: `fast.ru-3a` -> `fast`.
+		volumeType := strings.Split(createOpts.VolumeType, ".")[0]
+		resourceName := "volume_gigabytes_" + volumeType
+
+		filters = append(filters, quotas.WithResourceFilter(resourceName))
+	}
+
+	projectQuotas, _, err := quotas.GetProjectQuotas(
+		selvpcClient,
+		projectID,
+		region,
+		filters...,
+	)
+	if err != nil {
+		return errGettingObject(objectProjectQuotas, projectID, err)
+	}
+
+	return checkQuotasForNodegroup(projectQuotas, createOpts)
+}
+
 func checkQuotasForNodegroup(projectQuotas []*quotas.Quota, nodegroupOpts *nodegroup.CreateOpts) error {
 	var cpuQuotaChecked, ramQuotaChecked, diskQuotaChecked bool
 
@@ -903,3 +1234,158 @@ func waitForMKSNodegroupV1Creation(ctx context.Context, mksClient *v1.ServiceCli
 
 	return nodegroupID, nil
 }
+
+// mksNodegroupV1BlueGreenStep is a step of the blue/green replacement: the
+// new nodegroup is resized to NewNodes and then the Drain nodes of the old
+// nodegroup are drained.
+type mksNodegroupV1BlueGreenStep struct {
+	NewNodes int
+	Drain    []string
+}
+
+// mksNodegroupV1BlueGreenSteps splits the replacement into steps, so that the
+// number of old nodes drained in a step never exceeds the number of new
+// nodes added before it. Zero surge adds all new nodes in the first step.
+func mksNodegroupV1BlueGreenSteps(oldNodes []string, target, surge int) []mksNodegroupV1BlueGreenStep {
+	if surge <= 0 || surge > target {
+		surge = target
+	}
+	if surge == 0 {
+		return []mksNodegroupV1BlueGreenStep{{NewNodes: 0, Drain: oldNodes}}
+	}
+
+	var steps []mksNodegroupV1BlueGreenStep
+	for start := 0; start < len(oldNodes); start += surge {
+		steps = append(steps, mksNodegroupV1BlueGreenStep{
+			NewNodes: min(target, start+surge),
+			Drain:    oldNodes[start:min(start+surge, len(oldNodes))],
+		})
+	}
+	if len(steps) == 0 || steps[len(steps)-1].NewNodes < target {
+		steps = append(steps, mksNodegroupV1BlueGreenStep{NewNodes: target})
+	}
+
+	return steps
+}
+
+// replaceMKSNodegroupV1BlueGreen creates a nodegroup with the new flavor,
+// moves workloads to it by cordoning and draining the old nodes and deletes
+// the old nodegroup. If moving workloads fails, the old nodes are uncordoned
+// and the new nodegroup is deleted. If only deleting the old nodegroup fails,
+// the new nodegroup ID is returned together with the error.
+func replaceMKSNodegroupV1BlueGreen(
+	ctx context.Context, d *schema.ResourceData, mksClient *v1.ServiceClient, selvpcClient *selvpcclient.Client, clusterID, oldNodegroupID string,
+) (string, error) {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	drainTimeout := 15 * time.Minute
+	surge := 0
+	if settings := d.Get("blue_green").([]interface{}); len(settings) > 0 && settings[0] != nil {
+		blueGreen := settings[0].(map[string]interface{})
+		surge = blueGreen["surge_nodes"].(int)
+		drainTimeout, _ = time.ParseDuration(blueGreen["drain_timeout"].(string))
+	}
+
+	oldNodegroup, _, err := nodegroup.Get(ctx, mksClient, clusterID, oldNodegroupID)
+	if err != nil {
+		return "", errGettingObject(objectNodegroup, oldNodegroupID, err)
+	}
+	oldNodes := make([]string, len(oldNodegroup.Nodes))
+	for i, oldNode := range oldNodegroup.Nodes {
+		oldNodes[i] = oldNode.Hostname
+	}
+
+	createOpts, err := expandMKSNodegroupV1CreateOpts(d)
+	if err != nil {
+		return "", err
+	}
+	steps := mksNodegroupV1BlueGreenSteps(oldNodes, createOpts.Count, surge)
+	createOpts.Count = steps[0].NewNodes
+
+	if err := checkMKSNodegroupV1Quotas(selvpcClient, d.Get("project_id").(string), d.Get("region").(string), createOpts); err != nil {
+		return "", err
+	}
+
+	kubeconfig, _, err := cluster.GetParsedKubeconfig(ctx, mksClient, clusterID)
+	if err != nil {
+		return "", errGettingObject(objectKubeConfig, clusterID, err)
+	}
+	kubeClient, err := newMKSKubeClient(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+
+	allNodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
+	if err != nil {
+		return "", errGettingObject("all nodegroups in the cluster", clusterID, err)
+	}
+	nodegroupIDs := make(map[string]struct{}, len(allNodegroups))
+	for _, ng := range allNodegroups {
+		nodegroupIDs[ng.ID] = struct{}{}
+	}
+
+	log.Print(msgCreate(objectNodegroup, createOpts))
+	_, err = nodegroup.Create(ctx, mksClient, clusterID, createOpts)
+	if err != nil {
+		return "", err
+	}
+	newNodegroupID, err := waitForMKSNodegroupV1Creation(ctx, mksClient, clusterID, timeout, nodegroupIDs)
+	if err != nil {
+		return "", err
+	}
+
+	rollback := func(cause error) error {
+		errs := []error{cause}
+		for _, nodeName := range oldNodes {
+			if err := kubeClient.setNodeUnschedulable(ctx, nodeName, false); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		log.Print(msgDelete(objectNodegroup, newNodegroupID))
+		if _, err := nodegroup.Delete(ctx, mksClient, clusterID, newNodegroupID); err != nil {
+			errs = append(errs, fmt.Errorf("new nodegroup %s is left in cluster %s and has to be deleted manually: %w", newNodegroupID, clusterID, err))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	// All old nodes are cordoned first, so evicted pods are scheduled
+	// only on the nodes of the new nodegroup.
+	for _, nodeName := range oldNodes {
+		log.Printf("[DEBUG] cordoning node %s of nodegroup %s", nodeName, oldNodegroupID)
+		if err := kubeClient.setNodeUnschedulable(ctx, nodeName, true); err != nil {
+			return "", rollback(err)
+		}
+	}
+
+	size := steps[0].NewNodes
+	for _, step := range steps {
+		if step.NewNodes > size {
+			resizeOpts := nodegroup.ResizeOpts{Desired: step.NewNodes}
+			log.Print(msgUpdate(objectNodegroup, newNodegroupID, resizeOpts))
+			if _, err := nodegroup.Resize(ctx, mksClient, clusterID, newNodegroupID, &resizeOpts); err != nil {
+				return "", rollback(err)
+			}
+			if err := waitForMKSNodegroupV1ActiveState(ctx, mksClient, clusterID, newNodegroupID, timeout); err != nil {
+				return "", rollback(err)
+			}
+			size = step.NewNodes
+		}
+
+		for _, nodeName := range step.Drain {
+			log.Printf("[DEBUG] draining node %s of nodegroup %s", nodeName, oldNodegroupID)
+			if err := kubeClient.drainNode(ctx, nodeName, drainTimeout); err != nil {
+				return "", rollback(err)
+			}
+		}
+	}
+
+	log.Print(msgDelete(objectNodegroup, oldNodegroupID))
+	if _, err := nodegroup.Delete(ctx, mksClient, clusterID, oldNodegroupID); err != nil {
+		return newNodegroupID, fmt.Errorf("workloads were moved to nodegroup %s, but old nodegroup %s wasn't deleted: %w", newNodegroupID, oldNodegroupID, err)
+	}
+	if err := waitForMKSNodegroupV1Deletion(ctx, mksClient, clusterID, oldNodegroupID, timeout); err != nil {
+		return newNodegroupID, fmt.Errorf("error waiting for the old nodegroup %s to become deleted: %w", oldNodegroupID, err)
+	}
+
+	return newNodegroupID, nil
+}
@@ -0,0 +1,262 @@
+package selectel
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+// mksDrainPollInterval is the interval between eviction attempts and pod
+// checks while a node is drained.
+var mksDrainPollInterval = 5 * time.Second
+
+// mksKubeClient is a minimal client of the Kubernetes API of an MKS cluster
+// that is used to cordon and drain nodes.
+type mksKubeClient struct {
+	server     string
+	httpClient *http.Client
+}
+
+type mksKubePodList struct {
+	Items []mksKubePod `json:"items"`
+}
+
+type mksKubePod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Annotations     map[string]string `json:"annotations"`
+		OwnerReferences []struct {
+			Kind string `json:"kind"`
+		} `json:"ownerReferences"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// evictable reports whether the pod has to be evicted to drain the node.
+// DaemonSet and mirror pods can't be moved to other nodes, finished pods
+// don't need to be moved.
+func (p mksKubePod) evictable() bool {
+	if _, ok := p.Metadata.Annotations["kubernetes.io/config.mirror"]; ok {
+		return false
+	}
+	for _, owner := range p.Metadata.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return p.Status.Phase != "Succeeded" && p.Status.Phase != "Failed"
+}
+
+func newMKSKubeClient(kubeconfig *cluster.KubeconfigFields) (*mksKubeClient, error) {
+	caCert, err := base64.StdEncoding.DecodeString(kubeconfig.ClusterCA)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding cluster CA certificate: %w", err)
+	}
+	clientCert, err := base64.StdEncoding.DecodeString(kubeconfig.ClientCert)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client certificate: %w", err)
+	}
+	clientKey, err := base64.StdEncoding.DecodeString(kubeconfig.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client key: %w", err)
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("cluster CA certificate doesn't contain valid PEM data")
+	}
+	certificate, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate: %w", err)
+	}
+
+	return &mksKubeClient{
+		server: strings.TrimSuffix(kubeconfig.Server, "/"),
+		httpClient: &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					RootCAs:      certPool,
+					Certificates: []tls.Certificate{certificate},
+					MinVersion:   tls.VersionTLS12,
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *mksKubeClient) do(ctx context.Context, method, path, contentType string, body interface{}) (int, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error marshalling request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// setNodeUnschedulable cordons or uncordons the node.
+func (c *mksKubeClient) setNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": unschedulable,
+		},
+	}
+
+	status, body, err := c.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), "application/merge-patch+json", patch)
+	if err != nil {
+		return fmt.Errorf("error updating node %s: %w", nodeName, err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("error updating node %s: status %d: %s", nodeName, status, string(body))
+	}
+
+	return nil
+}
+
+func (c *mksKubeClient) nodePods(ctx context.Context, nodeName string) ([]mksKubePod, error) {
+	query := url.Values{"fieldSelector": {"spec.nodeName=" + nodeName}}
+	status, body, err := c.do(ctx, http.MethodGet, "/api/v1/pods?"+query.Encode(), "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of node %s: %w", nodeName, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("error listing pods of node %s: status %d: %s", nodeName, status, string(body))
+	}
+
+	var pods mksKubePodList
+	if err := json.Unmarshal(body, &pods); err != nil {
+		return nil, fmt.Errorf("error unmarshalling pods of node %s: %w", nodeName, err)
+	}
+
+	result := make([]mksKubePod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.evictable() {
+			result = append(result, pod)
+		}
+	}
+
+	return result, nil
+}
+
+// evictPod requests an eviction of the pod. It returns false when the
+// eviction is temporarily refused because of a PodDisruptionBudget.
+func (c *mksKubeClient) evictPod(ctx context.Context, pod mksKubePod) (bool, error) {
+	eviction := map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": map[string]string{
+			"name":      pod.Metadata.Name,
+			"namespace": pod.Metadata.Namespace,
+		},
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction",
+		url.PathEscape(pod.Metadata.Namespace), url.PathEscape(pod.Metadata.Name))
+	status, body, err := c.do(ctx, http.MethodPost, path, "application/json", eviction)
+	if err != nil {
+		return false, fmt.Errorf("error evicting pod %s/%s: %w", pod.Metadata.Namespace, pod.Metadata.Name, err)
+	}
+
+	switch status {
+	case http.StatusOK, http.StatusCreated, http.StatusNotFound:
+		return true, nil
+	case http.StatusTooManyRequests:
+		return false, nil
+	default:
+		return false, fmt.Errorf("error evicting pod %s/%s: status %d: %s",
+			pod.Metadata.Namespace, pod.Metadata.Name, status, string(body))
+	}
+}
+
+// drainNode evicts all pods that can be moved from the cordoned node and
+// waits until they are gone.
+func (c *mksKubeClient) drainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var pods []mksKubePod
+	timeoutErr := func() error {
+		names := make([]string, len(pods))
+		for i, pod := range pods {
+			names[i] = pod.Metadata.Namespace + "/" + pod.Metadata.Name
+		}
+
+		return fmt.Errorf("timeout draining node %s, pods left: %s", nodeName, strings.Join(names, ", "))
+	}
+
+	for {
+		var err error
+		pods, err = c.nodePods(ctx, nodeName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return timeoutErr()
+			}
+			return err
+		}
+		if len(pods) == 0 {
+			return nil
+		}
+
+		for _, pod := range pods {
+			evicted, err := c.evictPod(ctx, pod)
+			if err != nil {
+				if ctx.Err() != nil {
+					return timeoutErr()
+				}
+				return err
+			}
+			if !evicted {
+				log.Printf("[DEBUG] eviction of pod %s/%s is blocked by a disruption budget, retrying",
+					pod.Metadata.Namespace, pod.Metadata.Name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return timeoutErr()
+		case <-time.After(mksDrainPollInterval):
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMKSKubeClient(t *testing.T, handler http.Handler) *mksKubeClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &mksKubeClient{
+		server:     server.URL,
+		httpClient: server.Client(),
+	}
+}
+
+func TestMKSKubeClientSetNodeUnschedulable(t *testing.T) {
+	var (
+		contentType string
+		patch       map[string]map[string]bool
+	)
+	client := newTestMKSKubeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v1/nodes/node-1", r.URL.Path)
+		contentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &patch)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	err := client.setNodeUnschedulable(context.Background(), "node-1", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/merge-patch+json", contentType)
+	assert.True(t, patch["spec"]["unschedulable"])
+}
+
+func TestMKSKubeClientSetNodeUnschedulableErr(t *testing.T) {
+	client := newTestMKSKubeClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	err := client.setNodeUnschedulable(context.Background(), "node-1", true)
+
+	assert.ErrorContains(t, err, "status 403")
+}
+
+func TestMKSKubeClientDrainNode(t *testing.T) {
+	pollInterval := mksDrainPollInterval
+	mksDrainPollInterval = 10 * time.Millisecond
+	defer func() { mksDrainPollInterval = pollInterval }()
+
+	var (
+		mu        sync.Mutex
+		pods      = map[string]bool{"default/app": true}
+		attempts  int
+		evictions []string
+	)
+	client := newTestMKSKubeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/pods":
+			assert.Equal(t, "spec.nodeName=node-1", r.URL.Query().Get("fieldSelector"))
+			items := `{"metadata": {"name": "fluent-bit", "namespace": "kube-system", "ownerReferences": [{"kind": "DaemonSet"}]}},
+				{"metadata": {"name": "job", "namespace": "default"}, "status": {"phase": "Succeeded"}}`
+			if pods["default/app"] {
+				items += `, {"metadata": {"name": "app", "namespace": "default"}, "status": {"phase": "Running"}}`
+			}
+			_, _ = w.Write([]byte(`{"items": [` + items + `]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/namespaces/default/pods/app/eviction":
+			attempts++
+			// The first eviction is refused by a disruption budget.
+			if attempts == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			evictions = append(evictions, "default/app")
+			delete(pods, "default/app")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	err := client.drainNode(context.Background(), "node-1", time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{"default/app"}, evictions)
+}
+
+func TestMKSKubeClientDrainNodeTimeout(t *testing.T) {
+	pollInterval := mksDrainPollInterval
+	mksDrainPollInterval = 10 * time.Millisecond
+	defer func() { mksDrainPollInterval = pollInterval }()
+
+	client := newTestMKSKubeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"metadata": {"name": "app", "namespace": "default"}}]}`))
+	}))
+
+	err := client.drainNode(context.Background(), "node-1", 50*time.Millisecond)
+
+	assert.ErrorContains(t, err, "timeout draining node node-1, pods left: default/app")
+}
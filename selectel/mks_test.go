@@ -1,13 +1,19 @@
 package selectel
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
 	"github.com/selectel/mks-go/pkg/v1/node"
 	"github.com/selectel/mks-go/pkg/v1/nodegroup"
@@ -1285,3 +1291,210 @@ func TestCheckQuotasForNodegroupOk(t *testing.T) {
 
 	assert.NoError(t, checkQuotasForNodegroup(testQuotas, &testNodegroupOpts))
 }
+
+func TestExpandMKSNodegroupV1CreateOptsUserDataOnUpdate(t *testing.T) {
+	userData := "#cloud-config\npackages:\n  - htop\n"
+	state := &terraform.InstanceState{
+		ID: "cluster-1/nodegroup-1",
+		Attributes: map[string]string{
+			"id":                           "cluster-1/nodegroup-1",
+			"nodes_count":                  "2",
+			"flavor_id":                    "flavor-2",
+			"volume_type":                  "fast.ru-3a",
+			"install_nvidia_device_plugin": "false",
+			"user_data":                    userDataHash(userData),
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"user_data": cty.StringVal(userData),
+		}),
+	}
+	d := resourceMKSNodegroupV1().Data(state)
+
+	createOpts, err := expandMKSNodegroupV1CreateOpts(d)
+
+	assert.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(userData)), createOpts.UserData)
+	assert.Equal(t, "flavor-2", createOpts.FlavorID)
+}
+
+func TestMKSNodegroupV1BlueGreenSteps(t *testing.T) {
+	oldNodes := []string{"node-1", "node-2", "node-3"}
+
+	tableTests := []struct {
+		name     string
+		target   int
+		surge    int
+		expected []mksNodegroupV1BlueGreenStep
+	}{
+		{
+			name:   "all at once",
+			target: 3,
+			surge:  0,
+			expected: []mksNodegroupV1BlueGreenStep{
+				{NewNodes: 3, Drain: oldNodes},
+			},
+		},
+		{
+			name:   "one by one",
+			target: 3,
+			surge:  1,
+			expected: []mksNodegroupV1BlueGreenStep{
+				{NewNodes: 1, Drain: []string{"node-1"}},
+				{NewNodes: 2, Drain: []string{"node-2"}},
+				{NewNodes: 3, Drain: []string{"node-3"}},
+			},
+		},
+		{
+			name:   "scale up",
+			target: 5,
+			surge:  2,
+			expected: []mksNodegroupV1BlueGreenStep{
+				{NewNodes: 2, Drain: []string{"node-1", "node-2"}},
+				{NewNodes: 4, Drain: []string{"node-3"}},
+				{NewNodes: 5},
+			},
+		},
+		{
+			name:   "scale down",
+			target: 2,
+			surge:  1,
+			expected: []mksNodegroupV1BlueGreenStep{
+				{NewNodes: 1, Drain: []string{"node-1"}},
+				{NewNodes: 2, Drain: []string{"node-2"}},
+				{NewNodes: 2, Drain: []string{"node-3"}},
+			},
+		},
+		{
+			name:   "surge above target",
+			target: 2,
+			surge:  10,
+			expected: []mksNodegroupV1BlueGreenStep{
+				{NewNodes: 2, Drain: []string{"node-1", "node-2"}},
+				{NewNodes: 2, Drain: []string{"node-3"}},
+			},
+		},
+	}
+
+	for _, tt := range tableTests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mksNodegroupV1BlueGreenSteps(oldNodes, tt.target, tt.surge))
+		})
+	}
+}
+
+func TestFindMKSClusterV1ByName(t *testing.T) {
+	clusters := []*cluster.View{
+		{ID: "a", Name: "prod"},
+		{ID: "b", Name: "stage"},
+		{ID: "c", Name: "stage"},
+	}
+
+	found, err := findMKSClusterV1ByName(clusters, "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", found.ID)
+
+	_, err = findMKSClusterV1ByName(clusters, "dev")
+	assert.EqualError(t, err, `cluster with name "dev" is not found`)
+
+	_, err = findMKSClusterV1ByName(clusters, "stage")
+	assert.EqualError(t, err, `found 2 clusters with name "stage", use cluster_id instead`)
+}
+
+func TestFlattenMKSNodegroupsV1(t *testing.T) {
+	views := []*nodegroup.ListView{
+		{
+			BaseView: nodegroup.BaseView{
+				ID:               "ng",
+				Status:           nodegroup.StatusActive,
+				FlavorID:         "flavor",
+				VolumeGB:         20,
+				VolumeType:       "fast.ru-9a",
+				AvailabilityZone: "ru-9a",
+				Nodes: []*node.View{
+					{ID: "node", IP: "10.0.0.2", Hostname: "node-1"},
+				},
+				Labels: map[string]string{"key": "value"},
+				Taints: []nodegroup.Taint{
+					{Key: "key", Value: "value", Effect: nodegroup.NoScheduleEffect},
+				},
+				NodegroupType: "STANDARD",
+			},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"id":                           "ng",
+			"status":                       "ACTIVE",
+			"availability_zone":            "ru-9a",
+			"flavor_id":                    "flavor",
+			"volume_gb":                    20,
+			"volume_type":                  "fast.ru-9a",
+			"local_volume":                 false,
+			"nodes_count":                  1,
+			"enable_autoscale":             false,
+			"autoscale_min_nodes":          0,
+			"autoscale_max_nodes":          0,
+			"nodegroup_type":               "STANDARD",
+			"install_nvidia_device_plugin": false,
+			"preemptible":                  false,
+			"labels":                       map[string]string{"key": "value"},
+			"taints": []interface{}{
+				map[string]interface{}{"key": "key", "value": "value", "effect": "NoSchedule"},
+			},
+			"nodes": []map[string]interface{}{
+				{"id": "node", "ip": "10.0.0.2", "hostname": "node-1"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, flattenMKSNodegroupsV1(views))
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	assert.Equal(t, time.Hour, remainingTimeout(context.Background(), time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	assert.LessOrEqual(t, remainingTimeout(ctx, time.Hour), time.Minute)
+	assert.Equal(t, time.Second, remainingTimeout(ctx, time.Second))
+}
+
+func TestMKSClusterV1MinorUpgradePath(t *testing.T) {
+	versions := []*kubeversion.View{
+		{Version: "1.28.9"},
+		{Version: "1.29.4"},
+		{Version: "1.30.1"},
+		{Version: "1.32.0"},
+	}
+
+	path, err := mksClusterV1MinorUpgradePath(versions, "1.27.10", "1.30.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.28", "1.29", "1.30"}, path)
+
+	path, err = mksClusterV1MinorUpgradePath(versions, "1.28.9", "1.29.4")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.29"}, path)
+
+	_, err = mksClusterV1MinorUpgradePath(versions, "1.29.4", "1.32.0")
+	assert.EqualError(t, err, "intermediate minor version 1.31 isn't available, the cluster can't be upgraded from 1.29.4 to 1.32.0")
+
+	_, err = mksClusterV1MinorUpgradePath(versions, "1.30.1", "1.29.4")
+	assert.EqualError(t, err, "current version 1.30.1 can't be upgraded to version 1.29.4")
+}
+
+func TestMKSClusterV1UpgradeHopsDiags(t *testing.T) {
+	hops := []mksClusterV1UpgradeHop{
+		{From: "1.27.10", To: "1.28.9"},
+		{From: "1.28.9", To: "1.29.4"},
+	}
+
+	diags := mksClusterV1UpgradeHopsDiags("cluster", hops)
+
+	assert.Len(t, diags, 2)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Cluster cluster upgraded from 1.27.10 to 1.28.9", diags[0].Summary)
+	assert.Equal(t, "Cluster cluster upgraded from 1.28.9 to 1.29.4", diags[1].Summary)
+	assert.Empty(t, mksClusterV1UpgradeHopsDiags("cluster", nil))
+}
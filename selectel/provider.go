@@ -27,9 +27,11 @@ const (
 	objectGroup                     = "group"
 	objectGroupMembership           = "group-membership"
 	objectCluster                   = "cluster"
+	objectClusters                  = "clusters"
 	objectKubeConfig                = "kubeconfig"
 	objectKubeVersions              = "kube-versions"
 	objectNodegroup                 = "nodegroup"
+	objectNodegroups                = "nodegroups"
 	objectDomain                    = "domain"
 	objectRecord                    = "record"
 	objectZone                      = "zone"
@@ -50,6 +52,11 @@ const (
 	objectRegistryToken             = "registry token"
 	objectSecret                    = "secret"
 	objectCertificate               = "certificate"
+	objectDedicatedServer           = "dedicated server"
+	objectNetworkAttachment         = "network attachment"
+	objectPrivateNetwork            = "private network"
+	objectIPSubnet                  = "IP subnet"
+	objectDedicatedServerPool       = "dedicated server pool"
 )
 
 // This is a global MutexKV for use within this plugin.
@@ -117,16 +124,24 @@ func Provider() *schema.Provider {
 			"selectel_dbaas_flavor_v1":                  dataSourceDBaaSFlavorV1(),
 			"selectel_dbaas_configuration_parameter_v1": dataSourceDBaaSConfigurationParameterV1(),
 			"selectel_dbaas_prometheus_metric_token_v1": dataSourceDBaaSPrometheusMetricTokenV1(),
+			"selectel_mks_cluster_v1":                   dataSourceMKSClusterV1(),
+			"selectel_mks_nodegroups_v1":                dataSourceMKSNodegroupsV1(),
 			"selectel_mks_kubeconfig_v1":                dataSourceMKSKubeconfigV1(),
 			"selectel_mks_kube_versions_v1":             dataSourceMKSKubeVersionsV1(),
 			"selectel_mks_feature_gates_v1":             dataSourceMKSFeatureGatesV1(),
 			"selectel_mks_admission_controllers_v1":     dataSourceMKSAdmissionControllersV1(),
 			// Единичные data sources
-			"selectel_dedicated_server_location_v1":      dataSourceDedicatedServerLocationV1(),
-			"selectel_dedicated_server_configuration_v1": dataSourceDedicatedServerConfigurationV1(),
-			"selectel_dedicated_server_tariff_v1":        dataSourceDedicatedServerTariffV1(),
-			"selectel_dedicated_server_os_image_v1":      dataSourceDedicatedServerOSImageV1(),
-			"selectel_dedicated_server_network_v1":       dataSourceDedicatedServerNetworkV1(),
+			"selectel_dedicated_server_location_v1":       dataSourceDedicatedServerLocationV1(),
+			"selectel_dedicated_server_configuration_v1":  dataSourceDedicatedServerConfigurationV1(),
+			"selectel_dedicated_server_tariff_v1":         dataSourceDedicatedServerTariffV1(),
+			"selectel_dedicated_server_os_image_v1":       dataSourceDedicatedServerOSImageV1(),
+			"selectel_dedicated_server_network_v1":        dataSourceDedicatedServerNetworkV1(),
+			"selectel_dedicated_server_v1":                dataSourceDedicatedServerV1(),
+			"selectel_dedicated_server_availability_v1":   dataSourceDedicatedServerAvailabilityV1(),
+			"selectel_dedicated_server_price_estimate_v1": dataSourceDedicatedServerPriceEstimateV1(),
+			"selectel_dedicated_server_console_v1":        dataSourceDedicatedServerConsoleV1(),
+			"selectel_dedicated_server_hardware_v1":       dataSourceDedicatedServerHardwareV1(),
+			"selectel_dedicated_server_traffic_v1":        dataSourceDedicatedServerTrafficV1(),
 
 			// Множественные data sources
 			"selectel_dedicated_server_locations_v1":      dataSourceDedicatedServerLocationsV1(),
@@ -134,6 +149,7 @@ func Provider() *schema.Provider {
 			"selectel_dedicated_server_tariffs_v1":        dataSourceDedicatedServerTariffsV1(),
 			"selectel_dedicated_server_os_images_v1":      dataSourceDedicatedServerOSImagesV1(),
 			"selectel_dedicated_server_networks_v1":       dataSourceDedicatedServerNetworksV1(),
+			"selectel_dedicated_servers_v1":               dataSourceDedicatedServersV1(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"selectel_vpc_floatingip_v2":                            resourceVPCFloatingIPV2(),
@@ -176,6 +192,10 @@ func Provider() *schema.Provider {
 			"selectel_secretsmanager_secret_v1":                     resourceSecretsManagerSecretV1(),
 			"selectel_secretsmanager_certificate_v1":                resourceSecretsManagerCertificateV1(),
 			"selectel_dedicated_server_v1":                          resourceDedicatedServerV1(),
+			"selectel_dedicated_server_network_attachment_v1":       resourceDedicatedServerNetworkAttachmentV1(),
+			"selectel_dedicated_server_private_network_v1":          resourceDedicatedServerPrivateNetworkV1(),
+			"selectel_dedicated_server_ip_subnet_v1":                resourceDedicatedServerIPSubnetV1(),
+			"selectel_dedicated_server_pool_v1":                     resourceDedicatedServerPoolV1(),
 		},
 		ConfigureContextFunc: configureProvider,
 	}
@@ -25,6 +25,7 @@ func resourceDBaaSKafkaDatastoreV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDBaaSKafkaDatastoreV1ImportState,
 		},
+		CustomizeDiff: deletionProtectionDiff(objectDatastore, resourceDBaaSKafkaDatastoreV1Schema()),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -173,6 +174,10 @@ func resourceDBaaSKafkaDatastoreV1Update(ctx context.Context, d *schema.Resource
 }
 
 func resourceDBaaSKafkaDatastoreV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.FromErr(errDeletionProtected(objectDatastore, d.Id()))
+	}
+
 	dbaasClient, diagErr := getDBaaSClient(d, meta)
 	if diagErr != nil {
 		return diagErr
@@ -213,6 +218,7 @@ func resourceDBaaSKafkaDatastoreV1ImportState(_ context.Context, d *schema.Resou
 
 	d.Set("project_id", config.ProjectID)
 	d.Set("region", config.Region)
+	d.Set("deletion_protection", false)
 
 	return []*schema.ResourceData{d}, nil
 }
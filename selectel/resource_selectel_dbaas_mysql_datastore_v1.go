@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
@@ -26,8 +25,8 @@ func resourceDBaaSMySQLDatastoreV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDBaaSMySQLDatastoreV1ImportState,
 		},
-		CustomizeDiff: customdiff.All(
-			refreshDatastoreInstancesOutputsDiff,
+		CustomizeDiff: deletionProtectionDiff(objectDatastore, resourceDBaaSMySQLDatastoreV1Schema(),
+			withoutReplacement(refreshDatastoreInstancesOutputsDiff),
 		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -208,6 +207,10 @@ func resourceDBaaSMySQLDatastoreV1Update(ctx context.Context, d *schema.Resource
 }
 
 func resourceDBaaSMySQLDatastoreV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.FromErr(errDeletionProtected(objectDatastore, d.Id()))
+	}
+
 	dbaasClient, diagErr := getDBaaSClient(d, meta)
 	if diagErr != nil {
 		return diagErr
@@ -248,6 +251,7 @@ func resourceDBaaSMySQLDatastoreV1ImportState(_ context.Context, d *schema.Resou
 
 	d.Set("project_id", config.ProjectID)
 	d.Set("region", config.Region)
+	d.Set("deletion_protection", false)
 
 	return []*schema.ResourceData{d}, nil
 }
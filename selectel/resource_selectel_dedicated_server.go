@@ -2,11 +2,13 @@ package selectel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
@@ -21,6 +23,11 @@ func resourceDedicatedServerV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDedicatedServerV1ImportState,
 		},
+		CustomizeDiff: deletionProtectionDiff(objectDedicatedServer, resourceDedicatedServerV1Schema(),
+			withoutReplacement(validateDedicatedServerV1ReinstallDiff),
+			withoutReplacement(validateDedicatedServerV1OSParamsDiff),
+			withoutReplacement(dedicatedServerV1PreservePartitionsDiff),
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -48,6 +55,16 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 	tariffUUID := d.Get("tariff_uuid").(string)
 	osImageUUID := d.Get("os_image_uuid").(string)
 
+	// Выбор локации, в которой конфигурация есть в наличии, если указаны резервные локации
+	fallbackLocationUUIDs := convertToStringSlice(d.Get("fallback_location_uuids").([]interface{}))
+	if len(fallbackLocationUUIDs) > 0 {
+		var err error
+		locationUUID, err = selectAvailableLocation(ctx, client, configurationUUID, append([]string{locationUUID}, fallbackLocationUUIDs...))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("availability check failed: %w", err))
+		}
+	}
+
 	// Валидация локации
 	if err := validateLocation(ctx, client, locationUUID); err != nil {
 		return diag.FromErr(fmt.Errorf("location validation failed: %w", err))
@@ -86,6 +103,14 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	// Валидация подключаемых приватных сетей (если указаны)
+	privateNetworkUUIDs := convertToStringSlice(d.Get("private_network_uuids").(*schema.Set).List())
+	for _, networkUUID := range privateNetworkUUIDs {
+		if err := validatePrivateNetworkForConfiguration(ctx, client, networkUUID, configurationUUID, locationUUID); err != nil {
+			return diag.FromErr(fmt.Errorf("private network validation failed: %w", err))
+		}
+	}
+
 	// Валидация публичной сети (если указана)
 	if publicNetworkUUID := d.Get("public_network_uuid").(string); publicNetworkUUID != "" {
 		if err := validatePublicNetworkForLocation(ctx, client, publicNetworkUUID, locationUUID); err != nil {
@@ -126,6 +151,32 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(fmt.Errorf("server creation timeout: %w", err))
 	}
 
+	// Подключение приватных сетей
+	if len(privateNetworkUUIDs) > 0 {
+		for _, networkUUID := range privateNetworkUUIDs {
+			if err := attachDedicatedServerNetwork(ctx, client, server.UUID, networkUUID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err := client.WaitForServerStatus(ctx, server.UUID, ddaas.StatusActive, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(fmt.Errorf("private networks attachment timeout: %w", err))
+		}
+	}
+
+	// Настройка автопродления, если она указана в конфигурации и отличается
+	// от установленной по умолчанию
+	if autoRenew, ok := dedicatedServerV1ConfigAutoRenew(d); ok {
+		renewal, err := client.DedicatedServerRenewal(ctx, server.UUID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading renewal of dedicated server %s: %w", server.UUID, err))
+		}
+		if renewal.AutoRenew != autoRenew {
+			if err := updateDedicatedServerAutoRenew(ctx, client, server.UUID, autoRenew); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	return resourceDedicatedServerV1Read(ctx, d, meta)
 }
 
@@ -162,18 +213,49 @@ func resourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData,
 	d.Set("created_at", server.CreatedAt.Format(time.RFC3339))
 	d.Set("updated_at", server.UpdatedAt.Format(time.RFC3339))
 
-	// Установка IP адресов
-	if len(server.IPAddresses) > 0 {
-		ipAddresses := make([]map[string]interface{}, len(server.IPAddresses))
-		for i, ip := range server.IPAddresses {
-			ipAddresses[i] = map[string]interface{}{
-				"type":    ip.Type,
-				"ip":      ip.IP,
-				"netmask": ip.Netmask,
-				"gateway": ip.Gateway,
+	// Ошибки дополнительных запросов не прерывают обновление состояния,
+	// соответствующие атрибуты сохраняют прежние значения
+
+	// Установка информации о продлении
+	renewal, err := client.DedicatedServerRenewal(ctx, serverUUID)
+	if err != nil {
+		log.Printf("[WARN] Unable to read renewal of dedicated server %s, keeping the previous value: %v", serverUUID, err)
+	} else {
+		d.Set("auto_renew", renewal.AutoRenew)
+		if !renewal.PaidUntil.IsZero() {
+			d.Set("paid_until", renewal.PaidUntil.Format(time.RFC3339))
+		}
+	}
+
+	// Установка приватных сетей, подключенных самим ресурсом. Сети, подключенные
+	// другими способами, не попадают в состояние, чтобы не вызывать их отключение
+	attachments, err := client.NetworkAttachments(ctx, serverUUID)
+	if err != nil {
+		log.Printf("[WARN] Unable to read networks of dedicated server %s, keeping the previous value: %v", serverUUID, err)
+	} else {
+		managedNetworks := d.Get("private_network_uuids").(*schema.Set)
+		privateNetworkUUIDs := make([]string, 0, len(attachments))
+		for _, attachment := range attachments {
+			if attachment.Type == "private" && managedNetworks.Contains(attachment.NetworkUUID) {
+				privateNetworkUUIDs = append(privateNetworkUUIDs, attachment.NetworkUUID)
 			}
 		}
-		d.Set("ip_addresses", ipAddresses)
+		d.Set("private_network_uuids", privateNetworkUUIDs)
+	}
+
+	// Установка IP адресов, включая адреса привязанных к серверу подсетей
+	subnets, err := client.IPSubnets(ctx, &ddaas.IPSubnetQueryParams{
+		ServerUUID: serverUUID,
+	})
+	if err != nil {
+		log.Printf("[WARN] Unable to read IP subnets of dedicated server %s, keeping the previous value: %v", serverUUID, err)
+	} else {
+		ipAddresses := mergeDedicatedServerV1IPAddresses(server.IPAddresses, subnets)
+		if len(ipAddresses) > 0 {
+			d.Set("ip_addresses", flattenDedicatedServerV1IPAddresses(ipAddresses))
+		}
+		d.Set("primary_ipv4", dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion4))
+		d.Set("primary_ipv6", dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion6))
 	}
 
 	return nil
@@ -187,8 +269,28 @@ func resourceDedicatedServerV1Update(ctx context.Context, d *schema.ResourceData
 
 	serverUUID := d.Id()
 
-	// Обновление возможно только для os_image_uuid (переустановка ОС)
-	if d.HasChange("os_image_uuid") {
+	// Смена тарифа и периода оплаты без пересоздания сервера
+	if d.HasChange("tariff_uuid") {
+		if err := changeDedicatedServerTariff(ctx, d, client, serverUUID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Подключение и отключение приватных сетей без переустановки
+	if d.HasChange("private_network_uuids") {
+		if err := updateDedicatedServerPrivateNetworks(ctx, d, client, serverUUID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if autoRenew, ok := dedicatedServerV1ConfigAutoRenew(d); ok && d.HasChange("auto_renew") {
+		if err := updateDedicatedServerAutoRenew(ctx, client, serverUUID, autoRenew); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Изменение os_image_uuid или os_params требует переустановки ОС
+	if d.HasChanges(dedicatedServerV1ReinstallKeys...) {
 		return reinstallServerOS(ctx, d, meta, client, serverUUID)
 	}
 
@@ -203,6 +305,10 @@ func resourceDedicatedServerV1Delete(ctx context.Context, d *schema.ResourceData
 	}
 
 	serverUUID := d.Id()
+	if d.Get("deletion_protection").(bool) {
+		return diag.FromErr(errDeletionProtected(objectDedicatedServer, serverUUID))
+	}
+
 	log.Printf("[DEBUG] Deleting dedicated server %s", serverUUID)
 
 	// Удаление сервера
@@ -225,14 +331,182 @@ func resourceDedicatedServerV1Delete(ctx context.Context, d *schema.ResourceData
 
 func resourceDedicatedServerV1ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, fmt.Errorf("SELECTEL_PROJECT_ID must be set for the resource import")
+	projectID, region, serverRef, err := parseDedicatedServerV1ImportID(d.Id(), config.ProjectID, config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Поиск сервера по имени
+	if name, ok := strings.CutPrefix(serverRef, "name:"); ok {
+		client, err := newDedicatedServerClient(meta, projectID, region)
+		if err != nil {
+			return nil, err
+		}
+
+		serverRef, err = findDedicatedServerUUIDByName(ctx, client, projectID, name)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	d.Set("project_id", config.ProjectID)
+	d.SetId(serverRef)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
+	d.Set("deletion_protection", false)
 	return []*schema.ResourceData{d}, nil
 }
 
+// parseDedicatedServerV1ImportID разбирает ID импорта вида <uuid>,
+// <project_id>/<region>/<uuid> или <project_id>/<region>/name:<server-name>.
+// Для короткой формы проект и регион берутся из настроек провайдера.
+func parseDedicatedServerV1ImportID(id, defaultProjectID, defaultRegion string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+
+	switch len(parts) {
+	case 1:
+		if defaultProjectID == "" {
+			return "", "", "", errors.New("INFRA_PROJECT_ID must be set for the resource import")
+		}
+		if defaultRegion == "" {
+			return "", "", "", errors.New("INFRA_REGION must be set for the resource import")
+		}
+		return defaultProjectID, defaultRegion, id, nil
+	case 3:
+		if parts[0] != "" && parts[1] != "" && parts[2] != "" && parts[2] != "name:" {
+			return parts[0], parts[1], parts[2], nil
+		}
+	}
+
+	return "", "", "", fmt.Errorf(
+		"unable to parse dedicated server import ID: '%s', expected <uuid>, "+
+			"<project_id>/<region>/<uuid> or <project_id>/<region>/name:<server-name>", id,
+	)
+}
+
+// findDedicatedServerUUIDByName возвращает UUID сервера проекта с указанным именем.
+func findDedicatedServerUUIDByName(ctx context.Context, client *ddaas.API, projectID, name string) (string, error) {
+	servers, err := client.DedicatedServers(ctx, &ddaas.DedicatedServerQueryParams{
+		ProjectID: projectID,
+		Name:      name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error searching dedicated server %q: %w", name, err)
+	}
+
+	var uuids []string
+	for _, server := range servers {
+		if server.Name == name {
+			uuids = append(uuids, server.UUID)
+		}
+	}
+
+	switch len(uuids) {
+	case 0:
+		return "", fmt.Errorf("dedicated server %q not found in project %s", name, projectID)
+	case 1:
+		return uuids[0], nil
+	default:
+		return "", fmt.Errorf("found %d dedicated servers named %q in project %s, import by UUID instead", len(uuids), name, projectID)
+	}
+}
+
+// dedicatedServerV1ReinstallKeys - атрибуты, изменение которых приводит
+// к переустановке ОС.
+var dedicatedServerV1ReinstallKeys = []string{"os_image_uuid", "os_params"}
+
+// validateDedicatedServerV1ReinstallDiff запрещает изменения, требующие переустановки
+// ОС, без явного разрешения, так как переустановка стирает данные на дисках.
+func validateDedicatedServerV1ReinstallDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get("allow_reinstall").(bool) {
+		return nil
+	}
+
+	var changed []string
+	for _, key := range dedicatedServerV1ReinstallKeys {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"changing %s of dedicated server %s requires an OS reinstall that wipes all data on its disks "+
+			"except preserve_partitions, "+
+			"set allow_reinstall = true to proceed", strings.Join(changed, ", "), d.Id(),
+	)
+}
+
+// validateDedicatedServerV1OSParamsDiff проверяет os_params по схеме параметров
+// выбранного образа ОС на этапе планирования.
+func validateDedicatedServerV1OSParamsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges(dedicatedServerV1ReinstallKeys...) {
+		return nil
+	}
+	for _, key := range []string{"project_id", "region", "location_uuid", "configuration_uuid", "os_image_uuid", "os_params"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	client, err := newDedicatedServerClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+
+	osImageUUID := d.Get("os_image_uuid").(string)
+	image, err := client.OSImage(ctx, osImageUUID, d.Get("location_uuid").(string), d.Get("configuration_uuid").(string))
+	if err != nil {
+		// Наличие образа проверяется при создании сервера
+		log.Printf("[WARN] Unable to get OS image %s for os_params validation: %v", osImageUUID, err)
+		return nil
+	}
+
+	return validateDedicatedServerV1OSParams(dedicatedServerV1OSParams(d.Get("os_params").([]interface{})), *image)
+}
+
+// dedicatedServerV1PreservePartitionsDiff проверяет preserve_partitions по текущей
+// разметке сервера и показывает в плане разделы, которые сотрет переустановка ОС.
+// CustomizeDiff не может выводить предупреждения, поэтому partitions_to_erase
+// в плане единственный видимый пользователю сигнал, а предупреждение о стертых
+// разделах выводится после переустановки в Update.
+func dedicatedServerV1PreservePartitionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChanges(dedicatedServerV1ReinstallKeys...) {
+		return nil
+	}
+	for _, key := range []string{"project_id", "region", "os_params", "preserve_partitions"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("partitions_to_erase")
+		}
+	}
+
+	client, err := newDedicatedServerClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+
+	preserve := convertToStringSlice(d.Get("preserve_partitions").(*schema.Set).List())
+	current, err := client.DedicatedServerPartitions(ctx, d.Id())
+	if err != nil {
+		if len(preserve) > 0 {
+			return fmt.Errorf("error reading partitions of dedicated server %s to check preserve_partitions: %w", d.Id(), err)
+		}
+		log.Printf("[WARN] Unable to get partitions of dedicated server %s, the OS reinstall erases all of them: %v", d.Id(), err)
+		return d.SetNewComputed("partitions_to_erase")
+	}
+
+	oldRaid, newRaid := d.GetChange("os_params.0.soft_raid")
+	erased, err := dedicatedServerV1ErasedPartitions(
+		current, preserve, dedicatedServerV1OSParams(d.Get("os_params").([]interface{})), oldRaid != newRaid,
+	)
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("partitions_to_erase", dedicatedServerV1PartitionsMounts(erased))
+}
+
 // Функции валидации
 func validateProjectAccess(ctx context.Context, client *ddaas.API, projectID string) error {
 	// Проверяем доступ к проекту через получение списка серверов
@@ -253,6 +527,40 @@ func validateLocation(ctx context.Context, client *ddaas.API, locationUUID strin
 	return nil
 }
 
+// selectAvailableLocation возвращает первую локацию из списка, в которой
+// конфигурация есть в наличии.
+func selectAvailableLocation(ctx context.Context, client *ddaas.API, configUUID string, locationUUIDs []string) (string, error) {
+	for _, locationUUID := range locationUUIDs {
+		available, err := client.AvailableCount(ctx, configUUID, locationUUID)
+		if err != nil {
+			return "", fmt.Errorf("unable to get availability of configuration %s in location %s: %w", configUUID, locationUUID, err)
+		}
+		if available > 0 {
+			return locationUUID, nil
+		}
+
+		log.Printf("[DEBUG] Configuration %s is out of stock in location %s", configUUID, locationUUID)
+	}
+
+	return "", fmt.Errorf("configuration %s is out of stock in locations %s", configUUID, strings.Join(locationUUIDs, ", "))
+}
+
+// suppressFallbackLocationDiff не пересоздает сервер, если он был заказан
+// в одной из резервных локаций.
+func suppressFallbackLocationDiff(_, oldValue, _ string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+
+	for _, fallback := range d.Get("fallback_location_uuids").([]interface{}) {
+		if fallback.(string) == oldValue {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateConfigurationInLocation(ctx context.Context, client *ddaas.API, configUUID, locationUUID string) error {
 	configurations, err := client.Configurations(ctx, locationUUID)
 	if err != nil {
@@ -378,15 +686,28 @@ func processOSParams(ctx context.Context, client *ddaas.API, d *schema.ResourceD
 	if err != nil {
 		// Если не можем получить образ, просто передаем параметры как есть
 		log.Printf("[WARN] Unable to get OS image %s for validation: %v", osImageUUID, err)
+		image = nil
+	}
+
+	// Валидация параметров по схеме образа
+	if image != nil {
+		if err := validateDedicatedServerV1OSParams(dedicatedServerV1OSParams(osParamsList), *image); err != nil {
+			return nil, diag.FromErr(err)
+		}
 	}
 
 	// Обработка основных параметров
-	for _, key := range []string{"login", "password", "user_data"} {
+	for _, key := range []string{"login", "password"} {
 		if val, ok := osParams[key].(string); ok && val != "" {
 			processedParams[key] = val
 		}
 	}
 
+	// В state хранится только хэш user_data, поэтому значение берется из конфигурации
+	if userData := dedicatedServerV1ConfigUserData(d); userData != "" {
+		processedParams["user_data"] = userData
+	}
+
 	// Обработка SSH ключа
 	if sshKey, ok := osParams["ssh_key"].(string); ok && sshKey != "" {
 		// Если указано имя ключа, получаем его значение из Selectel
@@ -424,6 +745,42 @@ func processOSParams(ctx context.Context, client *ddaas.API, d *schema.ResourceD
 	return processedParams, nil
 }
 
+// dedicatedServerV1ConfigUserData возвращает os_params.user_data из конфигурации ресурса.
+func dedicatedServerV1ConfigUserData(d *schema.ResourceData) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	osParams := config.GetAttr("os_params")
+	if osParams.IsNull() || !osParams.IsKnown() || osParams.LengthInt() == 0 {
+		return ""
+	}
+
+	userData := osParams.Index(cty.NumberIntVal(0)).GetAttr("user_data")
+	if userData.IsNull() || !userData.IsKnown() {
+		return ""
+	}
+
+	return userData.AsString()
+}
+
+// dedicatedServerV1ConfigAutoRenew возвращает auto_renew из конфигурации ресурса,
+// если атрибут указан. Иначе автопродление не меняется.
+func dedicatedServerV1ConfigAutoRenew(d *schema.ResourceData) (bool, bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false, false
+	}
+
+	autoRenew := config.GetAttr("auto_renew")
+	if autoRenew.IsNull() || !autoRenew.IsKnown() {
+		return false, false
+	}
+
+	return autoRenew.True(), true
+}
+
 func validateSoftRaidForConfiguration(ctx context.Context, client *ddaas.API, softRaid, configUUID string) error {
 	config, err := client.Configuration(ctx, configUUID)
 	if err != nil {
@@ -479,10 +836,33 @@ func reinstallServerOS(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diagErr
 	}
 
+	// Проверка сохраняемых разделов по текущей разметке дисков. Без сохраняемых
+	// разделов переустановка стирает все, поэтому ошибка чтения разметки
+	// не прерывает ее, как и при построении плана
+	preserve := convertToStringSlice(d.Get("preserve_partitions").(*schema.Set).List())
+	var erased []ddaas.Partition
+	current, partitionsErr := client.DedicatedServerPartitions(ctx, serverUUID)
+	if partitionsErr != nil {
+		if len(preserve) > 0 {
+			return diag.FromErr(fmt.Errorf("error reading partitions of dedicated server %s: %w", serverUUID, partitionsErr))
+		}
+		log.Printf("[WARN] Unable to get partitions of dedicated server %s, the OS reinstall erases all of them: %v", serverUUID, partitionsErr)
+	} else {
+		oldRaid, newRaid := d.GetChange("os_params.0.soft_raid")
+		var err error
+		erased, err = dedicatedServerV1ErasedPartitions(
+			current, preserve, dedicatedServerV1OSParams(d.Get("os_params").([]interface{})), oldRaid != newRaid,
+		)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Вызов API для переустановки ОС
 	updateOpts := ddaas.DedicatedServerUpdateOpts{
-		OSImageUUID: newOSImageUUID,
-		OsParams:    osParams,
+		OSImageUUID:        newOSImageUUID,
+		OsParams:           osParams,
+		PreservePartitions: preserve,
 	}
 
 	_, err := client.UpdateDedicatedServer(ctx, serverUUID, updateOpts)
@@ -495,7 +875,112 @@ func reinstallServerOS(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.FromErr(fmt.Errorf("OS reinstall timeout: %w", err))
 	}
 
-	return resourceDedicatedServerV1Read(ctx, d, meta)
+	d.Set("partitions_to_erase", dedicatedServerV1PartitionsMounts(erased))
+
+	diags := resourceDedicatedServerV1Read(ctx, d, meta)
+	if partitionsErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("OS reinstall of dedicated server %s erased all partitions", serverUUID),
+			Detail:   fmt.Sprintf("Partitions of the server couldn't be listed before the reinstall: %v", partitionsErr),
+		})
+	} else if len(erased) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("OS reinstall of dedicated server %s erased %d partitions", serverUUID, len(erased)),
+			Detail:   dedicatedServerV1ErasedPartitionsDetail(erased),
+		})
+	}
+
+	return diags
+}
+
+func changeDedicatedServerTariff(ctx context.Context, d *schema.ResourceData, client *ddaas.API, serverUUID string) error {
+	tariffUUID := d.Get("tariff_uuid").(string)
+	configUUID := d.Get("configuration_uuid").(string)
+
+	if err := validateTariffForConfiguration(ctx, client, tariffUUID, configUUID); err != nil {
+		return fmt.Errorf("tariff validation failed: %w", err)
+	}
+
+	log.Printf("[DEBUG] Changing tariff of dedicated server %s to %s", serverUUID, tariffUUID)
+
+	_, err := client.ChangeDedicatedServerTariff(ctx, serverUUID, ddaas.DedicatedServerTariffChangeOpts{
+		TariffUUID: tariffUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("error changing tariff of dedicated server %s: %w", serverUUID, err)
+	}
+
+	return nil
+}
+
+func updateDedicatedServerAutoRenew(ctx context.Context, client *ddaas.API, serverUUID string, autoRenew bool) error {
+	log.Printf("[DEBUG] Setting auto renew of dedicated server %s to %t", serverUUID, autoRenew)
+
+	_, err := client.UpdateDedicatedServerRenewal(ctx, serverUUID, ddaas.RenewalUpdateOpts{
+		AutoRenew: autoRenew,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating renewal of dedicated server %s: %w", serverUUID, err)
+	}
+
+	return nil
+}
+
+func updateDedicatedServerPrivateNetworks(ctx context.Context, d *schema.ResourceData, client *ddaas.API, serverUUID string) error {
+	oldNetworks, newNetworks := d.GetChange("private_network_uuids")
+	networksToDetach := convertToStringSlice(oldNetworks.(*schema.Set).Difference(newNetworks.(*schema.Set)).List())
+	networksToAttach := convertToStringSlice(newNetworks.(*schema.Set).Difference(oldNetworks.(*schema.Set)).List())
+
+	configUUID := d.Get("configuration_uuid").(string)
+	locationUUID := d.Get("location_uuid").(string)
+	for _, networkUUID := range networksToAttach {
+		if err := validatePrivateNetworkForConfiguration(ctx, client, networkUUID, configUUID, locationUUID); err != nil {
+			return fmt.Errorf("private network validation failed: %w", err)
+		}
+	}
+
+	for _, networkUUID := range networksToDetach {
+		if err := detachDedicatedServerNetwork(ctx, client, serverUUID, networkUUID); err != nil {
+			return err
+		}
+	}
+	for _, networkUUID := range networksToAttach {
+		if err := attachDedicatedServerNetwork(ctx, client, serverUUID, networkUUID); err != nil {
+			return err
+		}
+	}
+
+	if err := client.WaitForServerStatus(ctx, serverUUID, ddaas.StatusActive, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("private networks update timeout: %w", err)
+	}
+
+	return nil
+}
+
+func attachDedicatedServerNetwork(ctx context.Context, client *ddaas.API, serverUUID, networkUUID string) error {
+	log.Printf("[DEBUG] Attaching network %s to dedicated server %s", networkUUID, serverUUID)
+
+	_, err := client.AttachNetwork(ctx, serverUUID, ddaas.NetworkAttachOpts{
+		NetworkUUID: networkUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("error attaching network %s to dedicated server %s: %w", networkUUID, serverUUID, err)
+	}
+
+	return nil
+}
+
+func detachDedicatedServerNetwork(ctx context.Context, client *ddaas.API, serverUUID, networkUUID string) error {
+	log.Printf("[DEBUG] Detaching network %s from dedicated server %s", networkUUID, serverUUID)
+
+	err := client.DetachNetwork(ctx, serverUUID, networkUUID)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return fmt.Errorf("error detaching network %s from dedicated server %s: %w", networkUUID, serverUUID, err)
+	}
+
+	return nil
 }
 
 func waitForServerDeleted(ctx context.Context, client *ddaas.API, serverUUID string, timeout time.Duration) error {
@@ -0,0 +1,185 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+func resourceDedicatedServerIPSubnetV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedServerIPSubnetV1Create,
+		ReadContext:   resourceDedicatedServerIPSubnetV1Read,
+		UpdateContext: resourceDedicatedServerIPSubnetV1Update,
+		DeleteContext: resourceDedicatedServerIPSubnetV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDedicatedServerIPSubnetV1ImportState,
+		},
+		CustomizeDiff: validateDedicatedServerIPSubnetV1PrefixDiff,
+		Schema:        resourceDedicatedServerIPSubnetV1Schema(),
+	}
+}
+
+func resourceDedicatedServerIPSubnetV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	locationUUID := d.Get("location_uuid").(string)
+	if err := validateLocation(ctx, client, locationUUID); err != nil {
+		return diag.FromErr(errCreatingObject(objectIPSubnet, err))
+	}
+
+	serverUUID := d.Get("server_uuid").(string)
+	if err := validateIPSubnetServerLocation(ctx, client, serverUUID, locationUUID); err != nil {
+		return diag.FromErr(errCreatingObject(objectIPSubnet, err))
+	}
+
+	createOpts := ddaas.IPSubnetCreateOpts{
+		LocationUUID: locationUUID,
+		IPVersion:    d.Get("ip_version").(int),
+		PrefixLength: d.Get("prefix_length").(int),
+		ServerUUID:   serverUUID,
+	}
+
+	log.Print(msgCreate(objectIPSubnet, createOpts))
+	subnet, err := client.CreateIPSubnet(ctx, createOpts)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectIPSubnet, err))
+	}
+
+	d.SetId(subnet.UUID)
+
+	return resourceDedicatedServerIPSubnetV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerIPSubnetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgGet(objectIPSubnet, d.Id()))
+	subnet, err := client.IPSubnet(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] IP subnet %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(errGettingObject(objectIPSubnet, d.Id(), err))
+	}
+
+	d.Set("location_uuid", subnet.LocationUUID)
+	d.Set("ip_version", subnet.IPVersion)
+	d.Set("prefix_length", subnet.PrefixLength)
+	d.Set("server_uuid", subnet.ServerUUID)
+	d.Set("cidr", subnet.CIDR)
+	d.Set("gateway", subnet.Gateway)
+	d.Set("addresses", subnet.Addresses)
+	d.Set("status", subnet.Status)
+
+	if err := d.Set("ip_addresses", flattenDedicatedServerV1IPAddresses(subnet.IPAddresses())); err != nil {
+		log.Print(errSettingComplexAttr("ip_addresses", err))
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerIPSubnetV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	if d.HasChange("server_uuid") {
+		serverUUID := d.Get("server_uuid").(string)
+		if err := validateIPSubnetServerLocation(ctx, client, serverUUID, d.Get("location_uuid").(string)); err != nil {
+			return diag.FromErr(errUpdatingObject(objectIPSubnet, d.Id(), err))
+		}
+
+		updateOpts := ddaas.IPSubnetUpdateOpts{
+			ServerUUID: serverUUID,
+		}
+
+		log.Print(msgUpdate(objectIPSubnet, d.Id(), updateOpts))
+		_, err := client.UpdateIPSubnet(ctx, d.Id(), updateOpts)
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectIPSubnet, d.Id(), err))
+		}
+	}
+
+	return resourceDedicatedServerIPSubnetV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerIPSubnetV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgDelete(objectIPSubnet, d.Id()))
+	err := client.DeleteIPSubnet(ctx, d.Id())
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(errDeletingObject(objectIPSubnet, d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerIPSubnetV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	if config.ProjectID == "" {
+		return nil, errors.New("INFRA_PROJECT_ID must be set for the resource import")
+	}
+	if config.Region == "" {
+		return nil, errors.New("INFRA_REGION must be set for the resource import")
+	}
+
+	d.Set("project_id", config.ProjectID)
+	d.Set("region", config.Region)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateDedicatedServerIPSubnetV1PrefixDiff проверяет, что длина префикса
+// допустима для выбранной версии IP.
+func validateDedicatedServerIPSubnetV1PrefixDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return validateIPSubnetPrefixLength(d.Get("ip_version").(int), d.Get("prefix_length").(int))
+}
+
+func validateIPSubnetPrefixLength(ipVersion, prefixLength int) error {
+	maxPrefixLength := 32
+	if ipVersion == 6 {
+		maxPrefixLength = 128
+	}
+	if prefixLength < 1 || prefixLength > maxPrefixLength {
+		return fmt.Errorf("prefix_length must be between 1 and %d for IPv%d subnet, got %d", maxPrefixLength, ipVersion, prefixLength)
+	}
+
+	return nil
+}
+
+// validateIPSubnetServerLocation проверяет, что сервер находится в локации подсети.
+func validateIPSubnetServerLocation(ctx context.Context, client *ddaas.API, serverUUID, locationUUID string) error {
+	if serverUUID == "" {
+		return nil
+	}
+
+	server, err := client.DedicatedServer(ctx, serverUUID)
+	if err != nil {
+		return fmt.Errorf("error reading dedicated server %s: %w", serverUUID, err)
+	}
+	if server.LocationUUID != locationUUID {
+		return fmt.Errorf("dedicated server %s is in location %s, but the subnet is in location %s", serverUUID, server.LocationUUID, locationUUID)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package selectel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIPSubnetPrefixLength(t *testing.T) {
+	assert.NoError(t, validateIPSubnetPrefixLength(4, 29))
+	assert.NoError(t, validateIPSubnetPrefixLength(6, 64))
+	assert.EqualError(t, validateIPSubnetPrefixLength(4, 64), "prefix_length must be between 1 and 32 for IPv4 subnet, got 64")
+	assert.EqualError(t, validateIPSubnetPrefixLength(6, 0), "prefix_length must be between 1 and 128 for IPv6 subnet, got 0")
+}
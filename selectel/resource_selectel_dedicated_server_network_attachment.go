@@ -0,0 +1,158 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+func resourceDedicatedServerNetworkAttachmentV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedServerNetworkAttachmentV1Create,
+		ReadContext:   resourceDedicatedServerNetworkAttachmentV1Read,
+		DeleteContext: resourceDedicatedServerNetworkAttachmentV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDedicatedServerNetworkAttachmentV1ImportState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: resourceDedicatedServerNetworkAttachmentV1Schema(),
+	}
+}
+
+func resourceDedicatedServerNetworkAttachmentV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverUUID := d.Get("server_uuid").(string)
+	networkUUID := d.Get("network_uuid").(string)
+
+	server, err := client.DedicatedServer(ctx, serverUUID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading dedicated server %s: %w", serverUUID, err))
+	}
+
+	// Проверка сети на совместимость с конфигурацией и локацией сервера
+	if err := validatePrivateNetworkForConfiguration(ctx, client, networkUUID, server.ConfigurationUUID, server.LocationUUID); err != nil {
+		return diag.FromErr(fmt.Errorf("private network validation failed: %w", err))
+	}
+
+	if err := attachDedicatedServerNetwork(ctx, client, serverUUID, networkUUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", serverUUID, networkUUID))
+
+	if err := client.WaitForServerStatus(ctx, serverUUID, ddaas.StatusActive, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("network attachment timeout: %w", err))
+	}
+
+	return resourceDedicatedServerNetworkAttachmentV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerNetworkAttachmentV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverUUID, networkUUID, err := parseDedicatedServerNetworkAttachmentID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgGet(objectNetworkAttachment, d.Id()))
+
+	attachments, err := client.NetworkAttachments(ctx, serverUUID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] Dedicated server %s not found, removing network attachment from state", serverUUID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(errGettingObject(objectNetworkAttachment, d.Id(), err))
+	}
+
+	for _, attachment := range attachments {
+		if attachment.NetworkUUID != networkUUID {
+			continue
+		}
+
+		d.Set("server_uuid", serverUUID)
+		d.Set("network_uuid", networkUUID)
+		d.Set("vlan", attachment.VLAN)
+
+		return nil
+	}
+
+	log.Printf("[WARN] Network %s is not attached to dedicated server %s, removing from state", networkUUID, serverUUID)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceDedicatedServerNetworkAttachmentV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverUUID, networkUUID, err := parseDedicatedServerNetworkAttachmentID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgDelete(objectNetworkAttachment, d.Id()))
+
+	if err := detachDedicatedServerNetwork(ctx, client, serverUUID, networkUUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.WaitForServerStatus(ctx, serverUUID, ddaas.StatusActive, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(fmt.Errorf("network detachment timeout: %w", err))
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerNetworkAttachmentV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	if config.ProjectID == "" {
+		return nil, errors.New("INFRA_PROJECT_ID must be set for the resource import")
+	}
+	if config.Region == "" {
+		return nil, errors.New("INFRA_REGION must be set for the resource import")
+	}
+
+	serverUUID, networkUUID, err := parseDedicatedServerNetworkAttachmentID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", config.ProjectID)
+	d.Set("region", config.Region)
+	d.Set("server_uuid", serverUUID)
+	d.Set("network_uuid", networkUUID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseDedicatedServerNetworkAttachmentID(id string) (string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", errParseID(objectNetworkAttachment, id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,23 @@
+package selectel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDedicatedServerNetworkAttachmentID(t *testing.T) {
+	serverUUID, networkUUID, err := parseDedicatedServerNetworkAttachmentID("srv-1/net-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "srv-1", serverUUID)
+	assert.Equal(t, "net-1", networkUUID)
+}
+
+func TestParseDedicatedServerNetworkAttachmentIDInvalid(t *testing.T) {
+	for _, id := range []string{"", "srv-1", "srv-1/", "/net-1", "srv-1/net-1/extra"} {
+		_, _, err := parseDedicatedServerNetworkAttachmentID(id)
+
+		assert.EqualError(t, err, "unable to parse network attachment ID: '"+id+"'")
+	}
+}
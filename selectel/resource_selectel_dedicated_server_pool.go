@@ -0,0 +1,602 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+// dedicatedServerPoolIndexPlaceholder заменяется в name_template на номер сервера в пуле
+const dedicatedServerPoolIndexPlaceholder = "{index}"
+
+// dedicatedServerPoolMember сервер пула
+type dedicatedServerPoolMember struct {
+	Index int
+	UUID  string
+	Name  string
+}
+
+// Импорт пула не поддерживается: пул существует только в состоянии Terraform,
+// API не хранит ни его ID, ни принадлежность к нему серверов, поэтому по
+// импортируемому ID нельзя надежно восстановить состав пула.
+func resourceDedicatedServerPoolV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedServerPoolV1Create,
+		ReadContext:   resourceDedicatedServerPoolV1Read,
+		UpdateContext: resourceDedicatedServerPoolV1Update,
+		DeleteContext: resourceDedicatedServerPoolV1Delete,
+		CustomizeDiff: deletionProtectionDiff(objectDedicatedServerPool, resourceDedicatedServerPoolV1Schema(),
+			withoutReplacement(dedicatedServerPoolV1ScaleDownDiff),
+			withoutReplacement(dedicatedServerPoolV1MembersDiff),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: resourceDedicatedServerPoolV1Schema(),
+	}
+}
+
+func resourceDedicatedServerPoolV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	serverCount := d.Get("server_count").(int)
+	createOpts, diagErr := validateDedicatedServerPoolV1Catalog(ctx, client, d, serverCount)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	d.SetId(resource.UniqueId())
+	d.Set("name_template", dedicatedServerPoolV1NameTemplate(d))
+
+	indexes := freeDedicatedServerPoolIndexes(nil, serverCount)
+	members, err := createDedicatedServerPoolMembers(ctx, client, d, createOpts, indexes)
+
+	if err != nil && len(members) == 0 {
+		d.SetId("")
+		return diag.FromErr(errCreatingObject(objectDedicatedServerPool, err))
+	}
+
+	if setErr := d.Set("members", flattenDedicatedServerPoolMembers(members, nil, nil)); setErr != nil {
+		log.Print(errSettingComplexAttr("members", setErr))
+	}
+	if err == nil {
+		return resourceDedicatedServerPoolV1Read(ctx, d, meta)
+	}
+
+	// При частичной ошибке пул создается из уже заказанных серверов: ошибка
+	// пометила бы пул tainted, и следующее применение пересоздало бы все
+	// серверы. Недостающие серверы закажет следующее применение через Update.
+	d.Set("server_count", len(members))
+	diags := resourceDedicatedServerPoolV1Read(ctx, d, meta)
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Dedicated server pool %s was created with %d of %d servers", d.Id(), len(members), serverCount),
+		Detail:   fmt.Sprintf("The next apply orders the missing servers. Some servers failed: %v", err),
+	})
+}
+
+func resourceDedicatedServerPoolV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgGet(objectDedicatedServerPool, d.Id()))
+
+	// Все серверы пула читаются одним запросом
+	servers, err := client.DedicatedServers(ctx, &ddaas.DedicatedServerQueryParams{
+		ProjectID: d.Get("project_id").(string),
+	})
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDedicatedServerPool, d.Id(), err))
+	}
+
+	serversByUUID := make(map[string]ddaas.DedicatedServer, len(servers))
+	for _, server := range servers {
+		serversByUUID[server.UUID] = server
+	}
+
+	// Адреса привязанных к серверам подсетей
+	subnets, err := client.IPSubnets(ctx, &ddaas.IPSubnetQueryParams{
+		LocationUUID: d.Get("location_uuid").(string),
+	})
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDedicatedServerPool, d.Id(), err))
+	}
+	subnetsByServer := make(map[string][]ddaas.IPSubnet)
+	for _, subnet := range subnets {
+		if subnet.ServerUUID != "" {
+			subnetsByServer[subnet.ServerUUID] = append(subnetsByServer[subnet.ServerUUID], subnet)
+		}
+	}
+
+	var members []dedicatedServerPoolMember
+	for _, member := range expandDedicatedServerPoolMembers(d.Get("members").([]interface{})) {
+		server, ok := serversByUUID[member.UUID]
+		if !ok || server.Status == ddaas.StatusDeleted {
+			log.Printf("[WARN] Dedicated server %s of pool %s not found, removing from state", member.UUID, d.Id())
+			continue
+		}
+		member.Name = server.Name
+		members = append(members, member)
+	}
+
+	if len(members) == 0 {
+		log.Printf("[WARN] Dedicated server pool %s has no servers left, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("server_count", len(members))
+	if err := d.Set("members", flattenDedicatedServerPoolMembers(members, serversByUUID, subnetsByServer)); err != nil {
+		log.Print(errSettingComplexAttr("members", err))
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerPoolV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	oldMembers, _ := d.GetChange("members")
+	members := expandDedicatedServerPoolMembers(oldMembers.([]interface{}))
+	serverCount := d.Get("server_count").(int)
+
+	// Уменьшение пула
+	if serverCount < len(members) {
+		if protected, _ := d.GetChange("deletion_protection"); protected.(bool) {
+			return diag.FromErr(errDeletionProtectedScaleDown(objectDedicatedServerPool, d.Id(), len(members), serverCount))
+		}
+
+		removeMembers := convertToStringSlice(d.Get("remove_members").(*schema.Set).List())
+		toRemove := selectDedicatedServerPoolMembersToRemove(members, serverCount, removeMembers)
+
+		log.Print(msgUpdate(objectDedicatedServerPool, d.Id(), fmt.Sprintf("remove %d servers", len(toRemove))))
+		err := deleteDedicatedServerPoolMembers(ctx, client, d, toRemove, d.Timeout(schema.TimeoutUpdate))
+
+		removed := make(map[string]struct{}, len(toRemove))
+		for _, member := range toRemove {
+			removed[member.UUID] = struct{}{}
+		}
+		var remaining []dedicatedServerPoolMember
+		for _, member := range members {
+			if _, ok := removed[member.UUID]; !ok {
+				remaining = append(remaining, member)
+			}
+		}
+		members = remaining
+
+		if err != nil {
+			d.Set("members", flattenDedicatedServerPoolMembers(members, nil, nil))
+			return diag.FromErr(errUpdatingObject(objectDedicatedServerPool, d.Id(), err))
+		}
+	}
+
+	// Увеличение пула
+	if serverCount > len(members) {
+		createOpts, diagErr := validateDedicatedServerPoolV1Catalog(ctx, client, d, serverCount-len(members))
+		if diagErr != nil {
+			return diagErr
+		}
+
+		indexes := freeDedicatedServerPoolIndexes(members, serverCount)
+		log.Print(msgUpdate(objectDedicatedServerPool, d.Id(), fmt.Sprintf("add servers %v", indexes)))
+		created, err := createDedicatedServerPoolMembers(ctx, client, d, createOpts, indexes)
+		members = append(members, created...)
+
+		if err != nil {
+			d.Set("members", flattenDedicatedServerPoolMembers(members, nil, nil))
+			return diag.FromErr(errUpdatingObject(objectDedicatedServerPool, d.Id(), err))
+		}
+	}
+
+	if err := d.Set("members", flattenDedicatedServerPoolMembers(members, nil, nil)); err != nil {
+		log.Print(errSettingComplexAttr("members", err))
+	}
+
+	return resourceDedicatedServerPoolV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerPoolV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.FromErr(errDeletionProtected(objectDedicatedServerPool, d.Id()))
+	}
+
+	members := expandDedicatedServerPoolMembers(d.Get("members").([]interface{}))
+
+	log.Print(msgDelete(objectDedicatedServerPool, d.Id()))
+	if err := deleteDedicatedServerPoolMembers(ctx, client, d, members, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(errDeletingObject(objectDedicatedServerPool, d.Id(), err))
+	}
+
+	return nil
+}
+
+// dedicatedServerPoolV1ScaleDownDiff запрещает уменьшение защищенного пула,
+// так как оно удаляет серверы.
+func dedicatedServerPoolV1ScaleDownDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("server_count") {
+		return nil
+	}
+
+	protected, _ := d.GetChange("deletion_protection")
+	oldCount, newCount := d.GetChange("server_count")
+	if protected.(bool) && newCount.(int) < oldCount.(int) {
+		return errDeletionProtectedScaleDown(objectDedicatedServerPool, d.Id(), oldCount.(int), newCount.(int))
+	}
+
+	return nil
+}
+
+// dedicatedServerPoolV1MembersDiff помечает members вычисляемыми при изменении
+// размера пула, так как состав серверов станет известен только после применения.
+func dedicatedServerPoolV1MembersDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("server_count") {
+		return nil
+	}
+
+	return d.SetNewComputed("members")
+}
+
+// validateDedicatedServerPoolV1Catalog проверяет каталог один раз для всего пула
+// и возвращает общие параметры заказа серверов.
+func validateDedicatedServerPoolV1Catalog(ctx context.Context, client *ddaas.API, d *schema.ResourceData, serverCount int) (ddaas.DedicatedServerCreateOpts, diag.Diagnostics) {
+	projectID := d.Get("project_id").(string)
+	locationUUID := d.Get("location_uuid").(string)
+	configurationUUID := d.Get("configuration_uuid").(string)
+	tariffUUID := d.Get("tariff_uuid").(string)
+	osImageUUID := d.Get("os_image_uuid").(string)
+
+	if err := validateLocation(ctx, client, locationUUID); err != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("location validation failed: %w", err))
+	}
+
+	if err := validateConfigurationInLocation(ctx, client, configurationUUID, locationUUID); err != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("configuration validation failed: %w", err))
+	}
+
+	available, err := client.AvailableCount(ctx, configurationUUID, locationUUID)
+	if err != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("availability check failed: %w", err))
+	}
+	if available < serverCount {
+		return ddaas.DedicatedServerCreateOpts{}, diag.Errorf(
+			"availability check failed: %d servers of configuration %s requested, but only %d are in stock in location %s",
+			serverCount, configurationUUID, available, locationUUID,
+		)
+	}
+
+	if err := validateTariffForConfiguration(ctx, client, tariffUUID, configurationUUID); err != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("tariff validation failed: %w", err))
+	}
+
+	if err := validateOSImageForConfiguration(ctx, client, osImageUUID, locationUUID, configurationUUID); err != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("OS image validation failed: %w", err))
+	}
+
+	if privateNetworkUUID := d.Get("private_network_uuid").(string); privateNetworkUUID != "" {
+		if err := validatePrivateNetworkForConfiguration(ctx, client, privateNetworkUUID, configurationUUID, locationUUID); err != nil {
+			return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("private network validation failed: %w", err))
+		}
+	}
+
+	if publicNetworkUUID := d.Get("public_network_uuid").(string); publicNetworkUUID != "" {
+		if err := validatePublicNetworkForLocation(ctx, client, publicNetworkUUID, locationUUID); err != nil {
+			return ddaas.DedicatedServerCreateOpts{}, diag.FromErr(fmt.Errorf("public network validation failed: %w", err))
+		}
+	}
+
+	osParams, diagErr := processOSParams(ctx, client, d, osImageUUID, configurationUUID)
+	if diagErr != nil {
+		return ddaas.DedicatedServerCreateOpts{}, diagErr
+	}
+
+	return ddaas.DedicatedServerCreateOpts{
+		ProjectID:          projectID,
+		LocationUUID:       locationUUID,
+		ConfigurationUUID:  configurationUUID,
+		TariffUUID:         tariffUUID,
+		OSImageUUID:        osImageUUID,
+		PublicNetworkUUID:  d.Get("public_network_uuid").(string),
+		PrivateNetworkUUID: d.Get("private_network_uuid").(string),
+		OsParams:           osParams,
+	}, nil
+}
+
+// createDedicatedServerPoolMembers заказывает серверы с указанными номерами,
+// одновременно выполняется не более max_parallel заказов. Возвращает все
+// успешно созданные серверы, даже если часть заказов завершилась ошибкой.
+func createDedicatedServerPoolMembers(ctx context.Context, client *ddaas.API, d *schema.ResourceData, createOpts ddaas.DedicatedServerCreateOpts, indexes []int) ([]dedicatedServerPoolMember, error) {
+	nameTemplate := d.Get("name_template").(string)
+
+	if err := validateDedicatedServerPoolMemberNames(ctx, client, createOpts.ProjectID, nameTemplate, indexes); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		members []dedicatedServerPoolMember
+	)
+
+	err := runDedicatedServerPoolTasks(ctx, len(indexes), d.Get("max_parallel").(int), func(i int) error {
+		opts := createOpts
+		opts.Name = dedicatedServerPoolMemberName(nameTemplate, indexes[i])
+
+		log.Print(msgCreate(objectDedicatedServer, opts.Name))
+		server, err := client.CreateDedicatedServer(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("error creating dedicated server %s: %w", opts.Name, err)
+		}
+
+		mu.Lock()
+		members = append(members, dedicatedServerPoolMember{
+			Index: indexes[i],
+			UUID:  server.UUID,
+			Name:  opts.Name,
+		})
+		mu.Unlock()
+
+		return nil
+	})
+
+	sortDedicatedServerPoolMembers(members)
+
+	uuids := make([]string, len(members))
+	for i, member := range members {
+		uuids[i] = member.UUID
+	}
+	if len(uuids) > 0 {
+		waitErr := client.WaitForServersStatus(ctx, createOpts.ProjectID, uuids, ddaas.StatusActive, d.Timeout(schema.TimeoutCreate))
+		if waitErr != nil {
+			err = errors.Join(err, fmt.Errorf("servers creation timeout: %w", waitErr))
+		}
+	}
+
+	return members, err
+}
+
+// deleteDedicatedServerPoolMembers удаляет серверы пула и ожидает их удаления.
+func deleteDedicatedServerPoolMembers(ctx context.Context, client *ddaas.API, d *schema.ResourceData, members []dedicatedServerPoolMember, timeout time.Duration) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	err := runDedicatedServerPoolTasks(ctx, len(members), d.Get("max_parallel").(int), func(i int) error {
+		log.Print(msgDelete(objectDedicatedServer, members[i].UUID))
+		err := client.DeleteDedicatedServer(ctx, members[i].UUID)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("error deleting dedicated server %s: %w", members[i].UUID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uuids := make([]string, len(members))
+	for i, member := range members {
+		uuids[i] = member.UUID
+	}
+	if err := client.WaitForServersStatus(ctx, d.Get("project_id").(string), uuids, ddaas.StatusDeleted, timeout); err != nil {
+		return fmt.Errorf("servers deletion timeout: %w", err)
+	}
+
+	return nil
+}
+
+// runDedicatedServerPoolTasks выполняет count задач, не более maxParallel одновременно,
+// и возвращает объединенные ошибки всех задач.
+func runDedicatedServerPoolTasks(ctx context.Context, count, maxParallel int, task func(i int) error) error {
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	semaphore := make(chan struct{}, maxParallel)
+
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			break
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := task(i); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// dedicatedServerPoolV1NameTemplate возвращает name_template пула. Шаблон
+// по умолчанию включает ID пула, чтобы имена серверов разных пулов не совпадали.
+func dedicatedServerPoolV1NameTemplate(d *schema.ResourceData) string {
+	if nameTemplate := d.Get("name_template").(string); nameTemplate != "" {
+		return nameTemplate
+	}
+
+	return d.Id() + "-server-" + dedicatedServerPoolIndexPlaceholder
+}
+
+// validateDedicatedServerPoolMemberNames проверяет одним запросом, что в проекте
+// нет серверов с именами, которые получат новые серверы пула.
+func validateDedicatedServerPoolMemberNames(ctx context.Context, client *ddaas.API, projectID, nameTemplate string, indexes []int) error {
+	servers, err := client.DedicatedServers(ctx, &ddaas.DedicatedServerQueryParams{
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to check server name uniqueness: %w", err)
+	}
+
+	names := make(map[string]struct{}, len(servers))
+	for _, server := range servers {
+		if server.Status != ddaas.StatusDeleted {
+			names[server.Name] = struct{}{}
+		}
+	}
+
+	var duplicates []string
+	for _, index := range indexes {
+		name := dedicatedServerPoolMemberName(nameTemplate, index)
+		if _, ok := names[name]; ok {
+			duplicates = append(duplicates, name)
+		}
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("servers with names %s already exist in project %s", strings.Join(duplicates, ", "), projectID)
+	}
+
+	return nil
+}
+
+func dedicatedServerPoolMemberName(nameTemplate string, index int) string {
+	return strings.ReplaceAll(nameTemplate, dedicatedServerPoolIndexPlaceholder, strconv.Itoa(index))
+}
+
+// freeDedicatedServerPoolIndexes возвращает наименьшие свободные номера серверов,
+// необходимые для увеличения пула до count серверов.
+func freeDedicatedServerPoolIndexes(members []dedicatedServerPoolMember, count int) []int {
+	used := make(map[int]struct{}, len(members))
+	for _, member := range members {
+		used[member.Index] = struct{}{}
+	}
+
+	var indexes []int
+	for index := 1; len(members)+len(indexes) < count; index++ {
+		if _, ok := used[index]; !ok {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes
+}
+
+// selectDedicatedServerPoolMembersToRemove выбирает серверы для уменьшения пула
+// до count серверов: сначала указанные в removeMembers (по UUID или имени),
+// затем серверы с наибольшими номерами.
+func selectDedicatedServerPoolMembersToRemove(members []dedicatedServerPoolMember, count int, removeMembers []string) []dedicatedServerPoolMember {
+	excess := len(members) - count
+	if excess <= 0 {
+		return nil
+	}
+
+	requested := make(map[string]struct{}, len(removeMembers))
+	for _, member := range removeMembers {
+		requested[member] = struct{}{}
+	}
+
+	sorted := make([]dedicatedServerPoolMember, len(members))
+	copy(sorted, members)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, iRequested := requested[sorted[i].UUID]
+		if _, ok := requested[sorted[i].Name]; ok {
+			iRequested = true
+		}
+		_, jRequested := requested[sorted[j].UUID]
+		if _, ok := requested[sorted[j].Name]; ok {
+			jRequested = true
+		}
+		if iRequested != jRequested {
+			return iRequested
+		}
+		return sorted[i].Index > sorted[j].Index
+	})
+
+	return sorted[:excess]
+}
+
+func sortDedicatedServerPoolMembers(members []dedicatedServerPoolMember) {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Index < members[j].Index
+	})
+}
+
+func expandDedicatedServerPoolMembers(membersList []interface{}) []dedicatedServerPoolMember {
+	members := make([]dedicatedServerPoolMember, 0, len(membersList))
+	for _, item := range membersList {
+		memberMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		members = append(members, dedicatedServerPoolMember{
+			Index: memberMap["index"].(int),
+			UUID:  memberMap["uuid"].(string),
+			Name:  memberMap["name"].(string),
+		})
+	}
+
+	return members
+}
+
+func flattenDedicatedServerPoolMembers(members []dedicatedServerPoolMember, servers map[string]ddaas.DedicatedServer, subnets map[string][]ddaas.IPSubnet) []map[string]interface{} {
+	sortDedicatedServerPoolMembers(members)
+
+	result := make([]map[string]interface{}, len(members))
+	for i, member := range members {
+		memberMap := map[string]interface{}{
+			"index": member.Index,
+			"uuid":  member.UUID,
+			"name":  member.Name,
+		}
+
+		if server, ok := servers[member.UUID]; ok {
+			ipAddresses := mergeDedicatedServerV1IPAddresses(server.IPAddresses, subnets[member.UUID])
+			memberMap["status"] = string(server.Status)
+			memberMap["ip_addresses"] = flattenDedicatedServerV1IPAddresses(ipAddresses)
+			memberMap["primary_ipv4"] = dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion4)
+			memberMap["primary_ipv6"] = dedicatedServerV1PrimaryIP(ipAddresses, ddaas.IPVersion6)
+			for _, ip := range ipAddresses {
+				if ip.Type == "public" {
+					memberMap["public_ip"] = ip.IP
+					break
+				}
+			}
+		}
+
+		result[i] = memberMap
+	}
+
+	return result
+}
@@ -0,0 +1,189 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+func TestDedicatedServerPoolMemberName(t *testing.T) {
+	assert.Equal(t, "k8s-node-3", dedicatedServerPoolMemberName("k8s-node-{index}", 3))
+}
+
+func TestDedicatedServerPoolV1NameTemplate(t *testing.T) {
+	d := resourceDedicatedServerPoolV1().TestResourceData()
+	d.SetId("pool-1")
+	assert.Equal(t, "pool-1-server-{index}", dedicatedServerPoolV1NameTemplate(d))
+
+	d.Set("name_template", "k8s-node-{index}")
+	assert.Equal(t, "k8s-node-{index}", dedicatedServerPoolV1NameTemplate(d))
+}
+
+func TestFlattenDedicatedServerPoolMembersSubnetAddresses(t *testing.T) {
+	members := []dedicatedServerPoolMember{
+		{Index: 2, UUID: "srv-2", Name: "node-2"},
+		{Index: 1, UUID: "srv-1", Name: "node-1"},
+	}
+	servers := map[string]ddaas.DedicatedServer{
+		"srv-1": {UUID: "srv-1", Status: ddaas.StatusActive, IPAddresses: []ddaas.IPAddress{
+			{Type: "private", IP: "10.0.0.5", Netmask: "255.255.255.0"},
+		}},
+		"srv-2": {UUID: "srv-2", Status: ddaas.StatusActive},
+	}
+	subnets := map[string][]ddaas.IPSubnet{
+		"srv-1": {{IPVersion: 4, PrefixLength: 29, Gateway: "198.51.100.1", Addresses: []string{"198.51.100.2"}}},
+	}
+
+	result := flattenDedicatedServerPoolMembers(members, servers, subnets)
+
+	assert.Equal(t, "srv-1", result[0]["uuid"])
+	assert.Len(t, result[0]["ip_addresses"], 2)
+	assert.Equal(t, "198.51.100.2", result[0]["primary_ipv4"])
+	assert.Equal(t, "198.51.100.2", result[0]["public_ip"])
+	assert.Empty(t, result[1]["ip_addresses"])
+}
+
+func TestFreeDedicatedServerPoolIndexes(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, freeDedicatedServerPoolIndexes(nil, 3))
+
+	members := []dedicatedServerPoolMember{
+		{Index: 1, UUID: "srv-1"},
+		{Index: 2, UUID: "srv-2"},
+		{Index: 4, UUID: "srv-4"},
+	}
+	assert.Equal(t, []int{3, 5}, freeDedicatedServerPoolIndexes(members, 5))
+	assert.Empty(t, freeDedicatedServerPoolIndexes(members, 3))
+}
+
+func TestSelectDedicatedServerPoolMembersToRemove(t *testing.T) {
+	members := []dedicatedServerPoolMember{
+		{Index: 1, UUID: "srv-1", Name: "node-1"},
+		{Index: 2, UUID: "srv-2", Name: "node-2"},
+		{Index: 3, UUID: "srv-3", Name: "node-3"},
+		{Index: 4, UUID: "srv-4", Name: "node-4"},
+	}
+
+	// По умолчанию удаляются серверы с наибольшими номерами
+	toRemove := selectDedicatedServerPoolMembersToRemove(members, 2, nil)
+	assert.Equal(t, []dedicatedServerPoolMember{members[3], members[2]}, toRemove)
+
+	// Явно указанные серверы удаляются первыми
+	toRemove = selectDedicatedServerPoolMembersToRemove(members, 2, []string{"node-1"})
+	assert.Equal(t, []dedicatedServerPoolMember{members[0], members[3]}, toRemove)
+
+	toRemove = selectDedicatedServerPoolMembersToRemove(members, 3, []string{"srv-2", "srv-unknown"})
+	assert.Equal(t, []dedicatedServerPoolMember{members[1]}, toRemove)
+
+	assert.Empty(t, selectDedicatedServerPoolMembersToRemove(members, 4, []string{"srv-2"}))
+}
+
+func TestRunDedicatedServerPoolTasks(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		running     int
+		maxRunning  int
+		completed   int
+		releaseTask = make(chan struct{})
+	)
+
+	go func() {
+		for i := 0; i < 10; i++ {
+			releaseTask <- struct{}{}
+		}
+	}()
+
+	err := runDedicatedServerPoolTasks(context.Background(), 10, 3, func(i int) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		<-releaseTask
+
+		mu.Lock()
+		running--
+		completed++
+		mu.Unlock()
+
+		if i == 4 {
+			return errors.New("task 4 failed")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "task 4 failed")
+	assert.Equal(t, 10, completed)
+	assert.LessOrEqual(t, maxRunning, 3)
+}
+
+func TestDedicatedServerPoolV1ScaleDownDiff(t *testing.T) {
+	tableTests := []struct {
+		name        string
+		protected   string
+		serverCount int
+		expectedErr string
+	}{
+		{
+			name:        "protected scale-up",
+			protected:   "true",
+			serverCount: 4,
+		},
+		{
+			name:        "protected scale-down",
+			protected:   "true",
+			serverCount: 2,
+			expectedErr: "decreasing server_count from 3 to 2 deletes servers of dedicated server pool 'pool-1', which has deletion protection enabled",
+		},
+		{
+			name:        "unprotected scale-down",
+			protected:   "false",
+			serverCount: 2,
+		},
+	}
+
+	for _, test := range tableTests {
+		t.Run(test.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "pool-1",
+				Attributes: map[string]string{
+					"id":                  "pool-1",
+					"project_id":          "project-1",
+					"region":              "ru-1",
+					"location_uuid":       "location-1",
+					"configuration_uuid":  "configuration-1",
+					"tariff_uuid":         "tariff-1",
+					"os_image_uuid":       "image-1",
+					"server_count":        "3",
+					"name_template":       "node-{index}",
+					"max_parallel":        "5",
+					"deletion_protection": test.protected,
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"project_id":          "project-1",
+				"region":              "ru-1",
+				"location_uuid":       "location-1",
+				"configuration_uuid":  "configuration-1",
+				"tariff_uuid":         "tariff-1",
+				"os_image_uuid":       "image-1",
+				"server_count":        test.serverCount,
+				"name_template":       "node-{index}",
+				"deletion_protection": test.protected == "true",
+			})
+
+			_, err := resourceDedicatedServerPoolV1().SimpleDiff(context.Background(), state, config, nil)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
+)
+
+func resourceDedicatedServerPrivateNetworkV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedServerPrivateNetworkV1Create,
+		ReadContext:   resourceDedicatedServerPrivateNetworkV1Read,
+		UpdateContext: resourceDedicatedServerPrivateNetworkV1Update,
+		DeleteContext: resourceDedicatedServerPrivateNetworkV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDedicatedServerPrivateNetworkV1ImportState,
+		},
+		Schema: resourceDedicatedServerPrivateNetworkV1Schema(),
+	}
+}
+
+func resourceDedicatedServerPrivateNetworkV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	locationUUID := d.Get("location_uuid").(string)
+	if err := validateLocation(ctx, client, locationUUID); err != nil {
+		return diag.FromErr(errCreatingObject(objectPrivateNetwork, err))
+	}
+
+	createOpts := ddaas.NetworkCreateOpts{
+		Name:         d.Get("name").(string),
+		LocationUUID: locationUUID,
+		Type:         "private",
+	}
+
+	log.Print(msgCreate(objectPrivateNetwork, createOpts))
+	network, err := client.CreateNetwork(ctx, createOpts)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectPrivateNetwork, err))
+	}
+
+	d.SetId(network.UUID)
+
+	return resourceDedicatedServerPrivateNetworkV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerPrivateNetworkV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgGet(objectPrivateNetwork, d.Id()))
+	network, err := client.Network(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] Private network %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(errGettingObject(objectPrivateNetwork, d.Id(), err))
+	}
+
+	d.Set("name", network.Name)
+	d.Set("location_uuid", network.LocationUUID)
+	d.Set("vlan", network.VLAN)
+
+	return nil
+}
+
+func resourceDedicatedServerPrivateNetworkV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	if d.HasChange("name") {
+		updateOpts := ddaas.NetworkUpdateOpts{
+			Name: d.Get("name").(string),
+		}
+
+		log.Print(msgUpdate(objectPrivateNetwork, d.Id(), updateOpts))
+		_, err := client.UpdateNetwork(ctx, d.Id(), updateOpts)
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectPrivateNetwork, d.Id(), err))
+		}
+	}
+
+	return resourceDedicatedServerPrivateNetworkV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerPrivateNetworkV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diagErr := getDedicatedServerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgDelete(objectPrivateNetwork, d.Id()))
+	err := client.DeleteNetwork(ctx, d.Id())
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(errDeletingObject(objectPrivateNetwork, d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerPrivateNetworkV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	if config.ProjectID == "" {
+		return nil, errors.New("INFRA_PROJECT_ID must be set for the resource import")
+	}
+	if config.Region == "" {
+		return nil, errors.New("INFRA_REGION must be set for the resource import")
+	}
+
+	d.Set("project_id", config.ProjectID)
+	d.Set("region", config.Region)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,35 @@
+package selectel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDedicatedServerV1ImportID(t *testing.T) {
+	projectID, region, serverRef, err := parseDedicatedServerV1ImportID("srv-1", "project-1", "ru-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"project-1", "ru-1", "srv-1"}, []string{projectID, region, serverRef})
+
+	projectID, region, serverRef, err = parseDedicatedServerV1ImportID("project-2/ru-2/srv-2", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"project-2", "ru-2", "srv-2"}, []string{projectID, region, serverRef})
+
+	projectID, region, serverRef, err = parseDedicatedServerV1ImportID("project-2/ru-2/name:db/primary", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"project-2", "ru-2", "name:db/primary"}, []string{projectID, region, serverRef})
+}
+
+func TestParseDedicatedServerV1ImportIDErrors(t *testing.T) {
+	_, _, _, err := parseDedicatedServerV1ImportID("srv-1", "", "ru-1")
+	assert.EqualError(t, err, "INFRA_PROJECT_ID must be set for the resource import")
+
+	_, _, _, err = parseDedicatedServerV1ImportID("srv-1", "project-1", "")
+	assert.EqualError(t, err, "INFRA_REGION must be set for the resource import")
+
+	for _, id := range []string{"project-1/srv-1", "project-1//srv-1", "project-1/ru-1/name:"} {
+		_, _, _, err = parseDedicatedServerV1ImportID(id, "project-1", "ru-1")
+		assert.EqualError(t, err, "unable to parse dedicated server import ID: '"+id+"', expected <uuid>, "+
+			"<project_id>/<region>/<uuid> or <project_id>/<region>/name:<server-name>")
+	}
+}
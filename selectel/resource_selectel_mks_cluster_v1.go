@@ -79,6 +79,11 @@ func resourceMKSClusterV1() *schema.Resource {
 				Optional: true,
 				ForceNew: false,
 			},
+			"allow_sequential_upgrades": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"enable_pod_security_policy": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -366,9 +371,17 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		return diagErr
 	}
 
+	var diags diag.Diagnostics
 	if d.HasChange("kube_version") {
-		if err := upgradeMKSClusterV1KubeVersion(ctx, d, mksClient); err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+		hops, err := upgradeMKSClusterV1KubeVersion(ctx, d, mksClient)
+		diags = mksClusterV1UpgradeHopsDiags(d.Id(), hops)
+		if err != nil {
+			// Keep the version the cluster was upgraded to before the failure.
+			if len(hops) > 0 {
+				d.Set("kube_version", hops[len(hops)-1].To)
+			}
+
+			return append(diags, diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))...)
 		}
 	}
 
@@ -393,14 +406,14 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 	if d.HasChange(featureGatesKey) {
 		v, err := getSetAsStrings(d, featureGatesKey)
 		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
+			return append(diags, diag.FromErr(errCreatingObject(objectCluster, err))...)
 		}
 		kubeOptions.FeatureGates = v
 	}
 	if d.HasChange(admissionControllersKey) {
 		v, err := getSetAsStrings(d, admissionControllersKey)
 		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
+			return append(diags, diag.FromErr(errCreatingObject(objectCluster, err))...)
 		}
 		kubeOptions.AdmissionControllers = v
 	}
@@ -411,7 +424,7 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 	if d.HasChange("oidc") {
 		oidc, err := expandAndValidateMKSClusterV1OIDC(d)
 		if err != nil {
-			return diag.FromErr(err)
+			return append(diags, diag.FromErr(err)...)
 		}
 		kubeOptions.OIDC = oidc
 	}
@@ -422,18 +435,18 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		log.Print(msgUpdate(objectCluster, d.Id(), updateOpts))
 		_, _, err := cluster.Update(ctx, mksClient, d.Id(), &updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+			return append(diags, diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))...)
 		}
 
 		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutUpdate)
 		err = waitForMKSClusterV1ActiveState(ctx, mksClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+			return append(diags, diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))...)
 		}
 	}
 
-	return resourceMKSClusterV1Read(ctx, d, meta)
+	return append(diags, resourceMKSClusterV1Read(ctx, d, meta)...)
 }
 
 func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -488,6 +501,7 @@ func resourceMKSClusterV1ImportState(_ context.Context, d *schema.ResourceData,
 
 	d.Set("project_id", config.ProjectID)
 	d.Set("region", config.Region)
+	d.Set("allow_sequential_upgrades", false)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -7,13 +7,11 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
@@ -81,24 +79,20 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Type:          schema.TypeInt,
 				ConflictsWith: []string{"flavor_id"},
 				Optional:      true,
-				ForceNew:      true,
 			},
 			"ram_mb": {
 				Type:          schema.TypeInt,
 				ConflictsWith: []string{"flavor_id"},
 				Optional:      true,
-				ForceNew:      true,
 			},
 			"volume_gb": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"volume_type": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"local_volume": {
 				Type:     schema.TypeBool,
@@ -109,7 +103,36 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
+			},
+			// Unset value means recreate, so existing nodegroups get no diff.
+			"flavor_change_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					mksNodegroupFlavorChangeRecreate,
+					mksNodegroupFlavorChangeBlueGreen,
+				}, false),
+			},
+			"blue_green": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"surge_nodes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"drain_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "15m",
+							ValidateFunc: validateMKSNodegroupV1Duration,
+						},
+					},
+				},
 			},
 			"labels": {
 				Type:     schema.TypeMap,
@@ -158,10 +181,12 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Computed: true,
 			},
 			"user_data": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 65535),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateEncodedUserData,
+				StateFunc:        userDataStateFunc,
+				DiffSuppressFunc: suppressUserDataDiff,
 			},
 			"install_nvidia_device_plugin": {
 				Type:     schema.TypeBool,
@@ -199,10 +224,8 @@ func resourceMKSNodegroupV1() *schema.Resource {
 			},
 		},
 		CustomizeDiff: customdiff.All(
-			// We need to recreate nodegroup if flavor changed.
-			customdiff.ForceNewIfChange("flavor_id", func(_ context.Context, oldVersion, newVersion, _ interface{}) bool {
-				return oldVersion.(string) != newVersion.(string)
-			}),
+			// We need to recreate nodegroup if flavor changed, unless it's replaced in place.
+			forceNewDiff(mksNodegroupV1FlavorChangeDiff),
 			customdiff.ForceNewIfChange("local_volume", func(_ context.Context, oldVersion, newVersion, _ interface{}) bool {
 				return oldVersion.(bool) != newVersion.(bool)
 			}),
@@ -248,84 +271,15 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	// Prepare nodegroup create options.
-	installNvidiaDevicePlugin := d.Get("install_nvidia_device_plugin").(bool)
-	preemptible := d.Get("preemptible").(bool)
-	createOpts := &nodegroup.CreateOpts{
-		Count:                     d.Get("nodes_count").(int),
-		FlavorID:                  d.Get("flavor_id").(string),
-		CPUs:                      d.Get("cpus").(int),
-		RAMMB:                     d.Get("ram_mb").(int),
-		VolumeGB:                  d.Get("volume_gb").(int),
-		VolumeType:                d.Get("volume_type").(string),
-		LocalVolume:               d.Get("local_volume").(bool),
-		KeypairName:               d.Get("keypair_name").(string),
-		AffinityPolicy:            d.Get("affinity_policy").(string),
-		AvailabilityZone:          d.Get("availability_zone").(string),
-		UserData:                  d.Get("user_data").(string),
-		InstallNvidiaDevicePlugin: &installNvidiaDevicePlugin,
-		Preemptible:               &preemptible,
-	}
-
-	if createOpts.LocalVolume && createOpts.VolumeType != "" {
-		return diag.FromErr(fmt.Errorf("can't use local_volume=true with volume_type: %w", err))
-	}
-	if !createOpts.LocalVolume && createOpts.VolumeType == "" {
-		return diag.FromErr(fmt.Errorf("can't use local_volume=false without specify volume_type: %w", err))
-	}
-	filters := []func(url.Values){
-		quotas.WithResourceFilter("compute_cores"),
-		quotas.WithResourceFilter("compute_ram"),
-	}
-	if createOpts.LocalVolume {
-		filters = append(filters, quotas.WithResourceFilter("volume_gigabytes_local"))
-	} else {
-		// Removing an availability zone from volume type.
-		// For example: `fast.ru-3a` -> `fast`.
-		volumeType := strings.Split(createOpts.VolumeType, ".")[0]
-		resourceName := "volume_gigabytes_" + volumeType
-
-		filters = append(filters, quotas.WithResourceFilter(resourceName))
-	}
-
-	projectQuotas, _, err := quotas.GetProjectQuotas(
-		selvpcClient,
-		projectID,
-		region,
-		filters...,
-	)
+	createOpts, err := expandMKSNodegroupV1CreateOpts(d)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
-	}
-
-	// Skip quota validation cause we can not open flavor and check resource claim.
-	if createOpts.FlavorID == "" {
-		if err := checkQuotasForNodegroup(projectQuotas, createOpts); err != nil {
-			return diag.FromErr(errCreatingObject(objectNodegroup, err))
-		}
+		return diag.FromErr(errCreatingObject(objectNodegroup, err))
 	}
 
-	// Check nodegroup autoscaling options.
-	if v, ok := d.GetOk("enable_autoscale"); ok {
-		enableAutoscale := v.(bool)
-		createOpts.EnableAutoscale = &enableAutoscale
-
-		// d.GetOk returns false on autoscale_min_nodes set as 0.
-		autoscaleMinNodes := d.Get("autoscale_min_nodes").(int)
-		createOpts.AutoscaleMinNodes = &autoscaleMinNodes
-
-		if v, ok := d.GetOk("autoscale_max_nodes"); ok {
-			autoscaleMaxNodes := v.(int)
-			createOpts.AutoscaleMaxNodes = &autoscaleMaxNodes
-		}
+	if err := checkMKSNodegroupV1Quotas(selvpcClient, projectID, region, createOpts); err != nil {
+		return diag.FromErr(errCreatingObject(objectNodegroup, err))
 	}
 
-	labels := d.Get("labels").(map[string]interface{})
-	createOpts.Labels = expandMKSNodegroupV1Labels(labels)
-
-	taints := d.Get("taints").([]interface{})
-	createOpts.Taints = expandMKSNodegroupV1Taints(taints)
-
 	log.Print(msgCreate(objectNodegroup, createOpts))
 	_, err = nodegroup.Create(ctx, mksClient, clusterID, createOpts)
 	if err != nil {
@@ -384,7 +338,7 @@ func resourceMKSNodegroupV1Read(ctx context.Context, d *schema.ResourceData, met
 	d.Set("autoscale_min_nodes", mksNodegroup.AutoscaleMinNodes)
 	d.Set("autoscale_max_nodes", mksNodegroup.AutoscaleMaxNodes)
 	d.Set("nodegroup_type", mksNodegroup.NodegroupType)
-	d.Set("user_data", mksNodegroup.UserData)
+	d.Set("user_data", userDataHash(mksNodegroup.UserData))
 	d.Set("install_nvidia_device_plugin", mksNodegroup.InstallNvidiaDevicePlugin)
 	d.Set("preemptible", mksNodegroup.Preemptible)
 
@@ -434,6 +388,22 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf("can't validate region: %w", err))
 	}
 
+	// Flavor changes are planned as updates only with the blue/green strategy,
+	// the new nodegroup is created with all other settings applied.
+	if d.HasChanges(mksNodegroupV1FlavorKeys...) {
+		newNodegroupID, err := replaceMKSNodegroupV1BlueGreen(ctx, d, mksClient, selvpcClient, clusterID, nodegroupID)
+		// Workloads may have been moved even if the old nodegroup wasn't
+		// deleted, the resource follows the new nodegroup then.
+		if newNodegroupID != "" {
+			d.SetId(fmt.Sprintf("%s/%s", clusterID, newNodegroupID))
+		}
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectNodegroup, nodegroupID, err))
+		}
+
+		return resourceMKSNodegroupV1Read(ctx, d, meta)
+	}
+
 	var (
 		updateOpts nodegroup.UpdateOpts
 		hasChanged bool
@@ -561,28 +531,8 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{strconv.Itoa(http.StatusOK)},
-		Target:  []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh: func() (result interface{}, state string, err error) {
-			result, response, err := nodegroup.Get(ctx, mksClient, clusterID, nodegroupID)
-			if err != nil {
-				if response != nil {
-					return result, strconv.Itoa(response.StatusCode), nil
-				}
-
-				return nil, "", err
-			}
-
-			return result, strconv.Itoa(response.StatusCode), err
-		},
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
 	log.Printf("[DEBUG] waiting for nodegroup %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	err = waitForMKSNodegroupV1Deletion(ctx, mksClient, clusterID, nodegroupID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error waiting for the nodegroup %s to become deleted: %s", d.Id(), err))
 	}
@@ -604,3 +554,65 @@ func resourceMKSNodegroupV1ImportState(_ context.Context, d *schema.ResourceData
 
 	return []*schema.ResourceData{d}, nil
 }
+
+const (
+	mksNodegroupFlavorChangeRecreate  = "recreate"
+	mksNodegroupFlavorChangeBlueGreen = "blue_green"
+)
+
+// mksNodegroupV1FlavorKeys are the attributes that define the flavor of nodes.
+var mksNodegroupV1FlavorKeys = []string{"cpus", "ram_mb", "volume_gb", "volume_type", "flavor_id"}
+
+// mksNodegroupV1FlavorChangeDiff forces a replacement of the nodegroup on
+// a flavor change, unless the blue/green strategy replaces it in place.
+func mksNodegroupV1FlavorChangeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}, forceNew forceNewFunc) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	var changed []string
+	for _, key := range mksNodegroupV1FlavorKeys {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if d.Get("flavor_change_strategy").(string) != mksNodegroupFlavorChangeBlueGreen {
+		for _, key := range changed {
+			if err := forceNew(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Computed attributes that aren't set in the configuration
+	// are taken from the new nodegroup.
+	config := d.GetRawConfig()
+	for _, key := range []string{"flavor_id", "volume_gb"} {
+		if !config.IsNull() && !config.GetAttr(key).IsNull() {
+			continue
+		}
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return d.SetNewComputed("nodes")
+}
+
+func validateMKSNodegroupV1Duration(v interface{}, k string) ([]string, []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration like 15m: %w", k, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("%s must be positive, got %s", k, duration)}
+	}
+
+	return nil, nil
+}
@@ -1,8 +1,11 @@
 package selectel
 
 import (
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/ddaas"
 )
 
 func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
@@ -13,11 +16,18 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 			ForceNew:    true,
 			Description: "Project ID",
 		},
-		"location_uuid": {
+		"region": {
 			Type:        schema.TypeString,
 			Required:    true,
 			ForceNew:    true,
-			Description: "Location UUID where server will be deployed",
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"location_uuid": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "Location UUID where server will be deployed",
+			DiffSuppressFunc: suppressFallbackLocationDiff,
 		},
 		"configuration_uuid": {
 			Type:        schema.TypeString,
@@ -28,8 +38,7 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 		"tariff_uuid": {
 			Type:        schema.TypeString,
 			Required:    true,
-			ForceNew:    true,
-			Description: "Tariff plan UUID",
+			Description: "Tariff plan UUID (can be changed without recreating the server)",
 		},
 		"os_image_uuid": {
 			Type:        schema.TypeString,
@@ -52,10 +61,29 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 			Description: "Public network UUID",
 		},
 		"private_network_uuid": {
-			Type:        schema.TypeString,
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			Description:   "Private network UUID (available only for supported configurations)",
+			ConflictsWith: []string{"private_network_uuids"},
+		},
+		"private_network_uuids": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Description: "Private network UUIDs attached to the server by this resource, changing them doesn't reinstall the server. " +
+				"Networks attached in other ways, e.g. with selectel_dedicated_server_network_attachment_v1, aren't reported",
+			ConflictsWith: []string{"private_network_uuid"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"fallback_location_uuids": {
+			Type:        schema.TypeList,
 			Optional:    true,
-			ForceNew:    true,
-			Description: "Private network UUID (available only for supported configurations)",
+			Description: "Location UUIDs to order the server in when the configuration is out of stock in location_uuid, in order of preference",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
 		},
 
 		// Дополнительные параметры для ОС
@@ -63,7 +91,7 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 			Type:        schema.TypeList,
 			Optional:    true,
 			MaxItems:    1,
-			Description: "Additional OS parameters",
+			Description: "Additional OS parameters (changing these reinstalls the OS, see allow_reinstall)",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"login": {
@@ -98,14 +126,44 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 						Description: "SSH key name or content (Linux only)",
 					},
 					"user_data": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "User data script (Linux only)",
+						Type:             schema.TypeString,
+						Optional:         true,
+						Description:      "Cloud-init user data: #cloud-config YAML, a script or a MIME multi-part archive, optionally base64 or gzip+base64 encoded (Linux only). Only its hash is stored in the state",
+						ValidateFunc:     validateUserData,
+						StateFunc:        userDataStateFunc,
+						DiffSuppressFunc: suppressUserDataDiff,
 					},
 				},
 			},
 		},
 
+		"allow_reinstall": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow reinstalling the OS when os_image_uuid or os_params change. Reinstall wipes all data on the server disks except preserve_partitions",
+		},
+
+		"preserve_partitions": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Mount points of partitions kept when the OS is reinstalled, for example /data. Root and boot partitions are always erased",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateDedicatedServerV1PreservePartition,
+			},
+		},
+
+		"auto_renew": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+			Description: "Automatically renew the server at the end of the paid period, " +
+				"the current setting is kept if not specified",
+		},
+
+		"deletion_protection": deletionProtectionSchema(),
+
 		// Вычисляемые атрибуты
 		"uuid": {
 			Type:        schema.TypeString,
@@ -122,35 +180,30 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Service UUID",
 		},
-		"ip_addresses": {
+		"partitions_to_erase": {
 			Type:        schema.TypeList,
 			Computed:    true,
-			Description: "Server IP addresses",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"type": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "IP address type (public/private)",
-					},
-					"ip": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "IP address",
-					},
-					"netmask": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Network mask",
-					},
-					"gateway": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Gateway IP",
-					},
-				},
+			Description: "Mount points of partitions erased by the planned or the last OS reinstall",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
+		"ip_addresses": dedicatedServerV1IPAddressesSchema("Server IP addresses"),
+		"primary_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "First public IPv4 address of the server",
+		},
+		"primary_ipv6": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "First public IPv6 address of the server",
+		},
+		"paid_until": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "End of the paid period",
+		},
 		"created_at": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -168,6 +221,16 @@ func resourceDedicatedServerV1Schema() map[string]*schema.Schema {
 
 func dataSourceDedicatedServerLocationV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
 		"uuid": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -195,11 +258,22 @@ func dataSourceDedicatedServerLocationV1Schema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Location availability",
 		},
+		"filter": dedicatedServerCatalogFilterSchema(nil),
 	}
 }
 
 func dataSourceDedicatedServerConfigurationV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
 		"uuid": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -242,11 +316,35 @@ func dataSourceDedicatedServerConfigurationV1Schema() map[string]*schema.Schema
 			Computed:    true,
 			Description: "Storage specifications",
 		},
+		"filter": dedicatedServerCatalogFilterSchema(map[string]*schema.Schema{
+			"min_cpu_cores": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum total number of CPU cores",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"min_ram_gb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum RAM size in GB",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		}),
 	}
 }
 
 func dataSourceDedicatedServerTariffV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
 		"uuid": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -279,11 +377,34 @@ func dataSourceDedicatedServerTariffV1Schema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Currency",
 		},
+		"filter": dedicatedServerCatalogFilterSchema(map[string]*schema.Schema{
+			"period": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Billing period",
+			},
+			"max_monthly_price": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Maximum price per month",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+		}),
 	}
 }
 
 func dataSourceDedicatedServerOSImageV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
 		"uuid": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -330,11 +451,51 @@ func dataSourceDedicatedServerOSImageV1Schema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
+		"filter": dedicatedServerCatalogFilterSchema(map[string]*schema.Schema{
+			"family": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "OS family (linux, windows)",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "OS version",
+			},
+			"architecture": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Architecture (x86_64, i386)",
+			},
+		}),
+		"most_recent": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Select the most recent image if more than one image matches",
+		},
+		"sort_by": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "version",
+			Description:  "Attribute used to find the most recent image (version or name)",
+			ValidateFunc: validation.StringInSlice([]string{"version", "name"}, false),
+		},
 	}
 }
 
 func dataSourceDedicatedServerNetworkV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
 		"uuid": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -366,5 +527,946 @@ func dataSourceDedicatedServerNetworkV1Schema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "VLAN ID",
 		},
+		"filter": dedicatedServerCatalogFilterSchema(nil),
+	}
+}
+
+// dedicatedServerV1CatalogItemSchema убирает из схемы единичного data source
+// аргументы поиска, чтобы использовать ее для элементов множественного data source.
+func dedicatedServerV1CatalogItemSchema(itemSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, key := range []string{"project_id", "region", "filter", "most_recent", "sort_by"} {
+		delete(itemSchema, key)
+	}
+
+	return itemSchema
+}
+
+func dataSourceDedicatedServerV1Schema() map[string]*schema.Schema {
+	serverSchema := dedicatedServerV1AttributesSchema()
+	serverSchema["project_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project ID",
+	}
+	serverSchema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Region of the dedicated server API endpoint",
+	}
+	serverSchema["uuid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Server UUID",
+	}
+	serverSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Server name",
+	}
+
+	return serverSchema
+}
+
+func dataSourceDedicatedServersV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by server status",
+			ValidateFunc: validation.StringInSlice([]string{
+				string(ddaas.StatusActive),
+				string(ddaas.StatusBuilding),
+				string(ddaas.StatusRebooting),
+				string(ddaas.StatusReinstall),
+				string(ddaas.StatusError),
+				string(ddaas.StatusMaintenance),
+			}, false),
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by location UUID",
+		},
+		"servers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: dedicatedServerV1AttributesSchema(),
+			},
+		},
+	}
+}
+
+// dedicatedServerV1AttributesSchema описывает вычисляемые атрибуты сервера,
+// общие для единичного и множественного data source.
+func dedicatedServerV1AttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server UUID",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server name",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server status",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Location UUID",
+		},
+		"service_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Service UUID",
+		},
+		"configuration_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server configuration UUID",
+		},
+		"tariff_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Tariff plan UUID",
+		},
+		"os_image_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "OS image UUID",
+		},
+		"ip_addresses": dedicatedServerV1IPAddressesSchema("Server IP addresses"),
+		"primary_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "First public IPv4 address of the server",
+		},
+		"primary_ipv6": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "First public IPv6 address of the server",
+		},
+		"configuration": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Server configuration details",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Configuration name",
+					},
+					"tariff_line": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Tariff line",
+					},
+					"model": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Server model",
+					},
+					"cpu": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "CPU specifications",
+					},
+					"ram": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "RAM specifications",
+					},
+					"storage": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Storage specifications",
+					},
+				},
+			},
+		},
+		"tariff": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Server tariff details",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Tariff name",
+					},
+					"period": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Billing period",
+					},
+					"price": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Price",
+					},
+					"currency": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Currency",
+					},
+				},
+			},
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server creation timestamp",
+		},
+		"updated_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server last update timestamp",
+		},
+	}
+}
+
+func dataSourceDedicatedServerAvailabilityV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by location UUID",
+		},
+		"configuration_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by configuration UUID",
+		},
+		"availability": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Number of servers available for order per configuration and location",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"configuration_uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Configuration UUID",
+					},
+					"location_uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Location UUID",
+					},
+					"available": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Number of servers available for order",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedServerPriceEstimateV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"configuration_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Server configuration UUID",
+		},
+		"tariff_uuid": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Tariff plan UUID (the cheapest tariff of the configuration is used if not specified, " +
+				"which requires all tariffs to be priced in the same currency)",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Location UUID used to look up prices of additional options",
+		},
+		"additional_public_ips": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			Description:  "Number of additional public IP addresses per server",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"private_network": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether each server is connected to a private network",
+		},
+		"server_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			Description:  "Number of servers to order",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"currency": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Currency",
+		},
+		"period": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Billing period of the tariff",
+		},
+		"monthly_total": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Total cost of the order per month",
+		},
+		"period_total": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Total cost of the order per billing period of the tariff",
+		},
+		"cheapest_tariff_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UUID of the cheapest tariff of the configuration in the currency of the selected tariff",
+		},
+		"tariffs": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Tariffs of the configuration with their monthly prices",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Tariff UUID",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Tariff name",
+					},
+					"period": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Billing period",
+					},
+					"price": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Price per billing period",
+					},
+					"monthly_price": {
+						Type:        schema.TypeFloat,
+						Computed:    true,
+						Description: "Price per month",
+					},
+					"currency": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Currency of the price",
+					},
+					"cheapest": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether this is the cheapest tariff of the configuration",
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDedicatedServerNetworkAttachmentV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"server_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Dedicated server UUID",
+		},
+		"network_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Private network UUID",
+		},
+		"vlan": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "VLAN ID of the attached network",
+		},
+	}
+}
+
+func resourceDedicatedServerPrivateNetworkV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Location UUID where the network will be created",
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Network name",
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"vlan": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "VLAN ID of the network",
+		},
+	}
+}
+
+func resourceDedicatedServerIPSubnetV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Location UUID where the subnet will be ordered",
+		},
+		"ip_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      4,
+			Description:  "IP version of the subnet (4 or 6)",
+			ValidateFunc: validation.IntInSlice([]int{4, 6}),
+		},
+		"prefix_length": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "Prefix length of the subnet",
+			ValidateFunc: validation.IntBetween(1, 128),
+		},
+		"server_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Dedicated server UUID the subnet is routed to (can be changed without reordering the subnet)",
+		},
+		"cidr": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Subnet CIDR",
+		},
+		"gateway": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Gateway IP",
+		},
+		"addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Usable IP addresses of the subnet",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ip_addresses": dedicatedServerV1IPAddressesSchema("Subnet IP addresses in the same format as ip_addresses of selectel_dedicated_server_v1"),
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Subnet status",
+		},
+	}
+}
+
+func dataSourceDedicatedServerConsoleV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"server_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Dedicated server UUID",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     string(ddaas.ConsoleTypeKVM),
+			Description: "Console type: kvm for a web console URL, ipmi for IPMI credentials",
+			ValidateFunc: validation.StringInSlice([]string{
+				string(ddaas.ConsoleTypeKVM),
+				string(ddaas.ConsoleTypeIPMI),
+			}, false),
+		},
+		"url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Time-limited KVM console URL",
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "IPMI host",
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "IPMI username",
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "IPMI password",
+		},
+		"expires_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time when the console session expires (RFC3339)",
+		},
+	}
+}
+
+func dataSourceDedicatedServerHardwareV1Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"server_uuid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Dedicated server UUID to read installed hardware of",
+			ExactlyOneOf: []string{"server_uuid", "configuration_uuid"},
+		},
+		"configuration_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Configuration UUID to read catalog hardware of without an ordered server",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server status, empty when configuration_uuid is used",
+		},
+		"health": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Overall health of the installed hardware, empty when configuration_uuid is used",
+		},
+		"cpu_model": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "CPU model",
+		},
+		"cpu_sockets": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of CPU sockets",
+		},
+		"cpu_cores": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Total number of CPU cores across all sockets",
+		},
+		"cpu_frequency_ghz": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "CPU base frequency in GHz",
+		},
+		"ram_gb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "RAM size in GB",
+		},
+		"disk_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of installed disks",
+		},
+		"disks": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Installed disks, one element per disk",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Disk type (HDD, SSD or NVMe)",
+					},
+					"size_gb": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Disk size in GB",
+					},
+					"model": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Disk model, empty when configuration_uuid is used",
+					},
+					"serial_number": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Disk serial number, empty when configuration_uuid is used",
+					},
+					"health": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Disk health, empty when configuration_uuid is used",
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDedicatedServerPoolV1Schema() map[string]*schema.Schema {
+	serverSchema := resourceDedicatedServerV1Schema()
+
+	// Параметры ОС общие для всех серверов пула и меняются только пересозданием пула
+	osParams := serverSchema["os_params"]
+	osParams.ForceNew = true
+	osParams.Description = "Additional OS parameters applied to every server of the pool"
+	for _, field := range osParams.Elem.(*schema.Resource).Schema {
+		field.ForceNew = true
+	}
+
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Project ID",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Region of the dedicated server API endpoint",
+		},
+		"location_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Location UUID where the servers will be deployed",
+		},
+		"configuration_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Server configuration UUID",
+		},
+		"tariff_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Tariff plan UUID",
+		},
+		"os_image_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "OS image UUID",
+		},
+		"public_network_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Public network UUID",
+		},
+		"private_network_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Private network UUID (available only for supported configurations)",
+		},
+		"os_params": osParams,
+		"server_count": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			Description:  "Number of servers in the pool, can't be decreased while deletion_protection is enabled",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"name_template": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+			Description: "Server name template, {index} is replaced with the number of the server in the pool " +
+				"(starting from 1). Defaults to <pool ID>-server-{index}",
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`\{index\}`), "must contain {index}"),
+		},
+		"remove_members": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Description: "UUIDs or names of servers to remove first when server_count is decreased, " +
+				"servers with the highest numbers are removed otherwise",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"max_parallel": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      5,
+			Description:  "Maximum number of servers ordered or deleted at the same time",
+			ValidateFunc: validation.IntBetween(1, 20),
+		},
+		"deletion_protection": deletionProtectionSchema(),
+		"members": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Servers of the pool",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Number of the server in the pool",
+					},
+					"uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Server UUID",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Server name",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Server status",
+					},
+					"public_ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "First public IP address of the server",
+					},
+					"primary_ipv4": serverSchema["primary_ipv4"],
+					"primary_ipv6": serverSchema["primary_ipv6"],
+					"ip_addresses": serverSchema["ip_addresses"],
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedServerTrafficV1Schema() map[string]*schema.Schema {
+	trafficStatSchema := func() map[string]*schema.Schema {
+		return map[string]*schema.Schema{
+			"inbound_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Inbound traffic in bytes",
+			},
+			"outbound_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Outbound traffic in bytes",
+			},
+			"inbound_95th_mbps": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "95th percentile of inbound bandwidth in Mbit/s",
+			},
+			"outbound_95th_mbps": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "95th percentile of outbound bandwidth in Mbit/s",
+			},
+		}
+	}
+
+	ipSchema := trafficStatSchema()
+	ipSchema["ip"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "IP address",
+	}
+
+	trafficSchema := trafficStatSchema()
+	trafficSchema["project_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project ID",
+	}
+	trafficSchema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Region of the dedicated server API endpoint",
+	}
+	trafficSchema["server_uuid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Dedicated server UUID",
+	}
+	trafficSchema["ip"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Return statistics only for this IP address of the server",
+		ValidateFunc: validation.IsIPAddress,
+	}
+	trafficSchema["from"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "Start of the period in RFC3339 format, the beginning of the current month (UTC) by default",
+		ValidateFunc: validation.IsRFC3339Time,
+	}
+	trafficSchema["to"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "End of the period in RFC3339 format, the current time by default",
+		ValidateFunc: validation.IsRFC3339Time,
+	}
+	trafficSchema["ip_addresses"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Traffic statistics per IP address of the server",
+		Elem: &schema.Resource{
+			Schema: ipSchema,
+		},
+	}
+
+	return trafficSchema
+}
+
+func dedicatedServerV1IPAddressesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "IP address type (public/private)",
+				},
+				"ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "IP address",
+				},
+				"ip_version": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "IP version of the address (4 or 6)",
+				},
+				"prefix_length": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Prefix length of the address network",
+				},
+				"cidr": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Address network in CIDR notation",
+				},
+				"netmask": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Network mask (IPv4 only)",
+				},
+				"gateway": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Gateway IP",
+				},
+			},
+		},
 	}
 }
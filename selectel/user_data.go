@@ -0,0 +1,307 @@
+package selectel
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// userDataMaxSize is the maximum size of user data accepted by the MKS and
+// dedicated servers APIs.
+const userDataMaxSize = 65535
+
+const userDataHashPrefix = "sha256:"
+
+var (
+	userDataHashRe = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+	userDataGzipMagic = []byte{0x1f, 0x8b}
+)
+
+// userDataHash returns the value stored in the state and shown in plans
+// instead of the whole user data. The hash is calculated over the decoded
+// content, so the same script passed as plain text, base64 or gzip+base64
+// doesn't produce a diff.
+func userDataHash(value string) string {
+	if value == "" || userDataHashRe.MatchString(value) {
+		return value
+	}
+
+	content, _, err := decodeUserData(value)
+	if err != nil {
+		content = []byte(value)
+	}
+	sum := sha256.Sum256(content)
+
+	return userDataHashPrefix + hex.EncodeToString(sum[:])
+}
+
+func userDataStateFunc(v interface{}) string {
+	return userDataHash(v.(string))
+}
+
+// suppressUserDataDiff ignores the difference between user data stored in the
+// state before hashing was introduced and the hash of the same content.
+func suppressUserDataDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return userDataHash(old) == userDataHash(new)
+}
+
+// validateUserData is a plan-time validation of the user data content.
+func validateUserData(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if value == "" {
+		return nil, nil
+	}
+
+	if len(value) > userDataMaxSize {
+		return nil, []error{fmt.Errorf("%s is %d bytes, the limit is %d bytes", k, len(value), userDataMaxSize)}
+	}
+
+	content, _, err := decodeUserData(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+	if err := validateUserDataContent(content); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// validateEncodedUserData is a plan-time validation of the user data that is
+// passed to the API in the base64 encoding. The limit is checked against the
+// value that will be sent, after automatic encoding.
+func validateEncodedUserData(v interface{}, k string) ([]string, []error) {
+	if _, err := encodeUserData(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// encodeUserData validates the user data and returns it base64-encoded.
+// Plain text user data is encoded automatically and is additionally gzipped
+// when the encoded value doesn't fit into the API limit. Already encoded
+// user data that fits into the limit is returned as is.
+func encodeUserData(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	content, encoded, err := decodeUserData(value)
+	if err != nil {
+		return "", err
+	}
+	if err := validateUserDataContent(content); err != nil {
+		return "", err
+	}
+
+	if encoded && len(value) <= userDataMaxSize {
+		return value, nil
+	}
+
+	result := base64.StdEncoding.EncodeToString(content)
+	if len(result) <= userDataMaxSize {
+		return result, nil
+	}
+
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if _, err := zw.Write(content); err != nil {
+		return "", fmt.Errorf("error compressing user data: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("error compressing user data: %w", err)
+	}
+
+	result = base64.StdEncoding.EncodeToString(buf.Bytes())
+	if len(result) > userDataMaxSize {
+		return "", fmt.Errorf("user data is %d bytes after gzip and base64 encoding, the limit is %d bytes", len(result), userDataMaxSize)
+	}
+
+	return result, nil
+}
+
+// decodeUserData returns the content of the user data passed as plain text,
+// base64 or gzip+base64. The second value reports whether the user data was
+// base64-encoded.
+func decodeUserData(value string) ([]byte, bool, error) {
+	content := []byte(value)
+	encoded := false
+
+	compact := strings.Join(strings.Fields(value), "")
+	if decoded, err := base64.StdEncoding.DecodeString(compact); err == nil && len(decoded) > 0 {
+		content = decoded
+		encoded = true
+	}
+
+	if bytes.HasPrefix(content, userDataGzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, encoded, fmt.Errorf("error decompressing user data: %w", err)
+		}
+		defer zr.Close()
+
+		decompressed, err := io.ReadAll(io.LimitReader(zr, 10*userDataMaxSize))
+		if err != nil {
+			return nil, encoded, fmt.Errorf("error decompressing user data: %w", err)
+		}
+		content = decompressed
+	}
+
+	return content, encoded, nil
+}
+
+// validateUserDataContent checks the content in one of the formats supported
+// by cloud-init: #cloud-config YAML, scripts, MIME multi-part archives and
+// other documents recognized by their first line. Jinja templates are rendered
+// on the node, so only their header is checked.
+func validateUserDataContent(content []byte) error {
+	firstLine := userDataFirstLine(content)
+
+	switch {
+	case firstLine == "#cloud-config":
+		return validateCloudConfig(content)
+	case firstLine == "#cloud-config-archive":
+		return validateCloudConfigArchive(content)
+	case strings.HasPrefix(firstLine, "#!"):
+		return validateUserDataScript(content)
+	case firstLine == "#include", firstLine == "#include-once", firstLine == "#cloud-boothook",
+		firstLine == "#part-handler", firstLine == "## template: jinja":
+		return nil
+	case isUserDataMIME(firstLine):
+		return validateUserDataMIME(content)
+	default:
+		return errors.New("unsupported user data format: it must start with #cloud-config, " +
+			"#! or another cloud-init header, or be a MIME multi-part archive")
+	}
+}
+
+func userDataFirstLine(content []byte) string {
+	line, _, _ := bytes.Cut(content, []byte("\n"))
+
+	return strings.TrimRight(string(line), "\r \t")
+}
+
+func isUserDataMIME(firstLine string) bool {
+	header := strings.ToLower(firstLine)
+
+	return strings.HasPrefix(header, "content-type:") || strings.HasPrefix(header, "mime-version:")
+}
+
+func validateCloudConfig(content []byte) error {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return fmt.Errorf("invalid #cloud-config YAML: %w", err)
+	}
+
+	return nil
+}
+
+func validateCloudConfigArchive(content []byte) error {
+	var archive []interface{}
+	if err := yaml.Unmarshal(content, &archive); err != nil {
+		return fmt.Errorf("invalid #cloud-config-archive YAML, it must be a list: %w", err)
+	}
+
+	return nil
+}
+
+func validateUserDataScript(content []byte) error {
+	firstLine := userDataFirstLine(content)
+	if strings.TrimSpace(strings.TrimPrefix(firstLine, "#!")) == "" {
+		return errors.New("script must specify an interpreter after #!")
+	}
+	if bytes.Contains(content, []byte("\r\n")) {
+		return errors.New("script must use LF line endings, CRLF breaks the interpreter line")
+	}
+
+	return nil
+}
+
+func validateUserDataMIME(content []byte) error {
+	msg, err := mail.ReadMessage(bufio.NewReader(bytes.NewReader(content)))
+	if err != nil {
+		return fmt.Errorf("invalid MIME user data: %w", err)
+	}
+
+	return validateUserDataMIMEPart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+}
+
+func validateUserDataMIMEPart(contentType, transferEncoding string, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid MIME content type %q: %w", contentType, err)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return fmt.Errorf("MIME content type %q has no boundary", contentType)
+		}
+
+		reader := multipart.NewReader(body, boundary)
+		parts := 0
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("invalid MIME multi-part user data: %w", err)
+			}
+			parts++
+
+			err = validateUserDataMIMEPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return fmt.Errorf("MIME part %d: %w", parts, err)
+			}
+		}
+		if parts == 0 {
+			return errors.New("MIME multi-part user data has no parts")
+		}
+
+		return nil
+	}
+
+	if strings.EqualFold(transferEncoding, "base64") {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("error reading MIME part: %w", err)
+	}
+
+	switch mediaType {
+	case "text/cloud-config":
+		return validateCloudConfig(content)
+	case "text/cloud-config-archive":
+		return validateCloudConfigArchive(content)
+	case "text/x-shellscript", "text/x-shellscript-per-boot", "text/x-shellscript-per-instance", "text/x-shellscript-per-once":
+		return validateUserDataScript(content)
+	case "text/cloud-boothook", "text/jinja2", "text/part-handler", "text/x-include-url", "text/x-include-once-url":
+		return nil
+	case "text/plain", "application/octet-stream":
+		if len(bytes.TrimSpace(content)) == 0 {
+			return nil
+		}
+
+		return validateUserDataContent(content)
+	default:
+		return fmt.Errorf("unsupported MIME content type %q", mediaType)
+	}
+}
@@ -0,0 +1,125 @@
+package selectel
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserDataScript = "#!/bin/bash -v\napt -y update\napt -y install mtr"
+
+const testUserDataMIME = `Content-Type: multipart/mixed; boundary="BOUNDARY"
+MIME-Version: 1.0
+
+--BOUNDARY
+Content-Type: text/cloud-config; charset="us-ascii"
+
+#cloud-config
+packages:
+  - mtr
+
+--BOUNDARY
+Content-Type: text/x-shellscript; charset="us-ascii"
+
+#!/bin/sh
+echo hello
+--BOUNDARY--
+`
+
+func testGzipBase64(t *testing.T, content string) string {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestValidateUserData(t *testing.T) {
+	valid := []string{
+		"",
+		testUserDataScript,
+		base64.StdEncoding.EncodeToString([]byte(testUserDataScript)),
+		testGzipBase64(t, testUserDataScript),
+		"#cloud-config\npackages:\n  - mtr\nruncmd:\n  - [ls, -l]\n",
+		"#cloud-config-archive\n- type: text/cloud-config\n  content: |\n    runcmd: [ls]\n",
+		"#include\nhttps://example.com/user-data\n",
+		"## template: jinja\n#cloud-config\nhostname: {{ v1.local_hostname }}\n",
+		testUserDataMIME,
+	}
+	for _, value := range valid {
+		_, errs := validateUserData(value, "user_data")
+		assert.Empty(t, errs, value)
+	}
+
+	invalid := map[string]string{
+		"#cloud-config\npackages: [mtr\n": "invalid #cloud-config YAML",
+		"#cloud-config\n- mtr\n":          "invalid #cloud-config YAML",
+		"apt -y install mtr":              "unsupported user data format",
+		"#!\necho hello\n":                "must specify an interpreter",
+		"#!/bin/sh\r\necho hello\r\n":     "CRLF",
+		strings.Replace(testUserDataMIME, "text/cloud-config", "text/cloud-cfg", 1): "MIME part 1: unsupported MIME content type",
+		strings.Replace(testUserDataMIME, "packages:\n", "packages: [\n", 1):        "MIME part 1: invalid #cloud-config YAML",
+		"#cloud-config\n" + strings.Repeat("a", userDataMaxSize):                    "the limit is 65535 bytes",
+	}
+	for value, expected := range invalid {
+		_, errs := validateUserData(value, "user_data")
+		if assert.Len(t, errs, 1, value) {
+			assert.Contains(t, errs[0].Error(), expected)
+		}
+	}
+}
+
+func TestEncodeUserData(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(testUserDataScript))
+
+	result, err := encodeUserData(testUserDataScript)
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, result)
+
+	result, err = encodeUserData(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, result)
+
+	// Repetitive content doesn't fit into the limit as plain base64, but
+	// fits after compression.
+	large := "#cloud-config\nwrite_files:\n" + strings.Repeat("  - path: /tmp/file\n    content: hello\n", 2000)
+	result, err = encodeUserData(large)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(result), userDataMaxSize)
+	content, wasEncoded, err := decodeUserData(result)
+	assert.NoError(t, err)
+	assert.True(t, wasEncoded)
+	assert.Equal(t, large, string(content))
+
+	// Random content can't be compressed enough.
+	random := make([]byte, userDataMaxSize)
+	rand.New(rand.NewSource(1)).Read(random)
+	_, err = encodeUserData("#!/bin/sh\n# " + base64.StdEncoding.EncodeToString(random))
+	assert.ErrorContains(t, err, "after gzip and base64 encoding")
+
+	_, err = encodeUserData("echo hello")
+	assert.ErrorContains(t, err, "unsupported user data format")
+}
+
+func TestUserDataHash(t *testing.T) {
+	expected := "sha256:41f4432a2d536b52bd12d4903a4762f5a3e34ba5109fae1c54188df30374e640"
+
+	assert.Equal(t, "", userDataHash(""))
+	assert.Equal(t, expected, userDataHash(testUserDataScript))
+	assert.Equal(t, expected, userDataHash(base64.StdEncoding.EncodeToString([]byte(testUserDataScript))))
+	assert.Equal(t, expected, userDataHash(testGzipBase64(t, testUserDataScript)))
+	assert.Equal(t, expected, userDataHash(expected))
+
+	assert.True(t, suppressUserDataDiff("user_data", testUserDataScript, expected, nil))
+	assert.False(t, suppressUserDataDiff("user_data", testUserDataScript, userDataHash("#!/bin/sh\n"), nil))
+}